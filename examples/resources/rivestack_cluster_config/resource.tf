@@ -0,0 +1,34 @@
+resource "rivestack_cluster_config" "example" {
+  cluster_id = rivestack_cluster.example.id
+
+  users = ["app_user", "analytics"]
+
+  databases = [
+    {
+      name  = "myapp"
+      owner = "app_user"
+    },
+  ]
+
+  grants = [
+    {
+      username = "app_user"
+      database = "myapp"
+      access   = "write"
+    },
+    {
+      username = "analytics"
+      database = "myapp"
+      access   = "read"
+    },
+  ]
+
+  extensions = [
+    {
+      extension = "vector"
+      database  = "myapp"
+    },
+  ]
+
+  allowed_cidrs = ["10.0.0.0/8"]
+}
@@ -0,0 +1,13 @@
+resource "rivestack_cluster_connection_pool" "example" {
+  cluster_id        = rivestack_cluster.example.id
+  pool_mode         = "transaction"
+  default_pool_size = 20
+  max_client_conn   = 1000
+
+  databases = [
+    {
+      database  = "appdb"
+      pool_size = 50
+    },
+  ]
+}
@@ -4,3 +4,15 @@ resource "rivestack_cluster_grant" "example" {
   database   = rivestack_cluster_database.example.name
   access     = "read"
 }
+
+# Truncate and reference a single table, and let the user pass the access on.
+resource "rivestack_cluster_grant" "orders" {
+  cluster_id        = rivestack_cluster.example.id
+  username          = rivestack_cluster_user.example.username
+  database          = rivestack_cluster_database.example.name
+  object_type       = "table"
+  schema            = "reporting"
+  object_name       = "orders"
+  privileges        = ["SELECT", "TRUNCATE", "REFERENCES"]
+  with_grant_option = true
+}
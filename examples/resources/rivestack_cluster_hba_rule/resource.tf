@@ -0,0 +1,7 @@
+resource "rivestack_cluster_hba_rule" "analytics" {
+  cluster_id = rivestack_cluster.example.id
+  username   = rivestack_cluster_user.analytics.username
+  database   = "appdb"
+  cidr       = "10.20.0.0/16"
+  method     = "scram-sha-256"
+}
@@ -0,0 +1,11 @@
+resource "rivestack_cluster_parameters" "example" {
+  cluster_id = rivestack_cluster.example.id
+
+  parameters = {
+    work_mem                   = "64MB"
+    max_connections            = "200"
+    log_min_duration_statement = "500"
+  }
+
+  rolling_restart = true
+}
@@ -0,0 +1,8 @@
+resource "rivestack_cluster_switchover" "example" {
+  cluster_id  = rivestack_cluster.example.id
+  target_node = "rs-abc123-db-2"
+
+  triggers = {
+    maintenance = "2026-10-24"
+  }
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// catalogCache holds catalog responses that rarely change so plan-time
+// validation does not hit the API once per resource. Failed lookups are not
+// cached.
+type catalogCache struct {
+	mu          sync.Mutex
+	serverTypes *ServerTypesResponse
+	regions     *RegionsResponse
+	parameters  *ParameterCatalogResponse
+}
+
+// CachedServerTypes returns the server type catalog, fetching it on first use.
+func (c *Client) CachedServerTypes(ctx context.Context) (*ServerTypesResponse, error) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.serverTypes != nil {
+		return c.catalog.serverTypes, nil
+	}
+	resp, err := c.GetServerTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.catalog.serverTypes = resp
+	return resp, nil
+}
+
+// CachedRegions returns the region catalog, fetching it on first use.
+func (c *Client) CachedRegions(ctx context.Context) (*RegionsResponse, error) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.regions != nil {
+		return c.catalog.regions, nil
+	}
+	resp, err := c.GetRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.catalog.regions = resp
+	return resp, nil
+}
+
+// CachedParameterCatalog returns the server parameter catalog, fetching it on first use.
+func (c *Client) CachedParameterCatalog(ctx context.Context) (*ParameterCatalogResponse, error) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.parameters != nil {
+		return c.catalog.parameters, nil
+	}
+	resp, err := c.GetParameterCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.catalog.parameters = resp
+	return resp, nil
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,6 +21,8 @@ type Client struct {
 	APIKey     string
 	HTTPClient *http.Client
 	UserAgent  string
+
+	catalog catalogCache
 }
 
 // NewClient creates a new Rivestack API client.
@@ -70,6 +73,22 @@ func IsGone(err error) bool {
 	return false
 }
 
+// IsClusterFailed returns true if the error reports that the cluster entered
+// the "failed" status.
+func IsClusterFailed(err error) bool {
+	var failedErr *ClusterFailedError
+	return errors.As(err, &failedErr)
+}
+
+// IsUnsupported returns true if the API does not implement the requested
+// operation (501). Validation errors such as 400 and 422 are not included.
+func IsUnsupported(err error) bool {
+	if apiErr, ok := err.(*APIError); ok {
+		return apiErr.StatusCode == http.StatusNotImplemented
+	}
+	return false
+}
+
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
 	url := fmt.Sprintf("%s%s", c.BaseURL, path)
 
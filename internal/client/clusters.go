@@ -6,9 +6,53 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
+// AllowedIPs returns the cluster's source IP allowlist as a list of CIDRs.
+func (c *Cluster) AllowedIPs() []string {
+	var ips []string
+	for _, ip := range strings.Split(c.SourceIPs, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// WithSSLMode adds an sslmode parameter to a PostgreSQL connection URI that
+// does not already specify one: require when the cluster rejects plain
+// connections, prefer otherwise.
+func WithSSLMode(connectionString string, requireSSL bool) string {
+	if connectionString == "" {
+		return connectionString
+	}
+	u, err := url.Parse(connectionString)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return connectionString
+	}
+	q := u.Query()
+	if q.Get("sslmode") != "" {
+		return connectionString
+	}
+	if requireSSL {
+		q.Set("sslmode", "require")
+	} else {
+		q.Set("sslmode", "prefer")
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// DatabaseWide reports whether the grant covers a whole database rather than
+// a schema or individual objects.
+func (g *ClusterGrant) DatabaseWide() bool {
+	return g.ObjectType == "" || g.ObjectType == "database"
+}
+
 // ProvisionCluster creates a new HA cluster.
 func (c *Client) ProvisionCluster(ctx context.Context, req ProvisionClusterRequest) (*ProvisionClusterResponse, error) {
 	var resp ProvisionClusterResponse
@@ -39,13 +83,106 @@ func (c *Client) ListClusters(ctx context.Context) ([]Cluster, error) {
 	return resp.Clusters, nil
 }
 
+// UpdateCluster updates mutable metadata of a cluster, such as its name.
+func (c *Client) UpdateCluster(ctx context.Context, id int, req UpdateClusterRequest) (*Cluster, error) {
+	var cluster Cluster
+	err := c.doRequest(ctx, "PATCH", fmt.Sprintf("/api/ha/%d", id), req, &cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &cluster, nil
+}
+
+// PromoteCluster promotes a read replica cluster to a standalone primary.
+func (c *Client) PromoteCluster(ctx context.Context, id int) (*PromoteClusterResponse, error) {
+	var resp PromoteClusterResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/promote", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RotatePassword generates a new password for the cluster's default database user.
+func (c *Client) RotatePassword(ctx context.Context, id int) (*RotatePasswordResponse, error) {
+	var resp RotatePasswordResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/rotate-password", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // DeleteCluster initiates deletion of a cluster.
 func (c *Client) DeleteCluster(ctx context.Context, id int) error {
 	return c.doRequest(ctx, "DELETE", fmt.Sprintf("/api/ha/%d", id), nil, nil)
 }
 
+// ResizeStorage grows the storage of every node of a cluster. Storage cannot
+// be shrunk.
+func (c *Client) ResizeStorage(ctx context.Context, id int, req ResizeStorageRequest) (*ResizeStorageResponse, error) {
+	var resp ResizeStorageResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/storage", id), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PauseCluster stops the nodes of a cluster while keeping its data.
+func (c *Client) PauseCluster(ctx context.Context, id int) (*ClusterStateResponse, error) {
+	var resp ClusterStateResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/pause", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResumeCluster starts the nodes of a paused cluster.
+func (c *Client) ResumeCluster(ctx context.Context, id int) (*ClusterStateResponse, error) {
+	var resp ClusterStateResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/resume", id), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClusterFailedError is returned when a cluster enters the "failed" status
+// while it is being waited on.
+type ClusterFailedError struct {
+	Target  string
+	Message string
+}
+
+func (e *ClusterFailedError) Error() string {
+	return fmt.Sprintf("cluster failed before becoming %s: %s", e.Target, e.Message)
+}
+
 // WaitForClusterActive polls the cluster until it reaches "active" or "failed" status.
 func (c *Client) WaitForClusterActive(ctx context.Context, id int, timeout time.Duration) (*Cluster, error) {
+	return c.waitForClusterStatus(ctx, id, "active", timeout, "provisioning", "restoring", "resuming")
+}
+
+// WaitForClusterResumed polls the cluster until it reaches "active" or "failed"
+// status after a resume request. Unlike WaitForClusterActive it keeps polling
+// while the cluster still reports "paused", since the request may not be
+// reflected in the status right away.
+func (c *Client) WaitForClusterResumed(ctx context.Context, id int, timeout time.Duration) (*Cluster, error) {
+	return c.waitForClusterStatus(ctx, id, "active", timeout, "resuming", "paused")
+}
+
+// WaitForClusterPaused polls the cluster until it reaches "paused" or "failed"
+// status. It keeps polling while the cluster still reports "active", since the
+// pause request may not be reflected in the status right away.
+func (c *Client) WaitForClusterPaused(ctx context.Context, id int, timeout time.Duration) (*Cluster, error) {
+	return c.waitForClusterStatus(ctx, id, "paused", timeout, "pausing", "active")
+}
+
+// waitForClusterStatus polls the cluster until it reaches target, failing on
+// "failed" or any status not listed in transitional.
+func (c *Client) waitForClusterStatus(ctx context.Context, id int, target string, timeout time.Duration, transitional ...string) (*Cluster, error) {
 	deadline := time.Now().Add(timeout)
 	pollInterval := 15 * time.Second
 
@@ -55,15 +192,15 @@ func (c *Client) WaitForClusterActive(ctx context.Context, id int, timeout time.
 			return nil, fmt.Errorf("polling cluster status: %w", err)
 		}
 
-		switch cluster.Status {
-		case "active":
+		switch {
+		case cluster.Status == target:
 			return cluster, nil
-		case "failed":
-			return nil, fmt.Errorf("cluster provisioning failed: %s", cluster.ErrorMessage)
-		case "provisioning":
+		case cluster.Status == "failed":
+			return nil, &ClusterFailedError{Target: target, Message: cluster.ErrorMessage}
+		case slices.Contains(transitional, cluster.Status):
 			// Continue polling.
 		default:
-			return nil, fmt.Errorf("unexpected cluster status: %s", cluster.Status)
+			return nil, fmt.Errorf("unexpected cluster status while waiting for it to become %s: %s", target, cluster.Status)
 		}
 
 		select {
@@ -73,7 +210,7 @@ func (c *Client) WaitForClusterActive(ctx context.Context, id int, timeout time.
 		}
 	}
 
-	return nil, fmt.Errorf("timeout waiting for cluster to become active after %s", timeout)
+	return nil, fmt.Errorf("timeout waiting for cluster to become %s after %s", target, timeout)
 }
 
 // WaitForClusterDeleted polls the cluster until it is deleted or gone.
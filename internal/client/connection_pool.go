@@ -0,0 +1,29 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetConnectionPool retrieves the connection pooler (PgBouncer) configuration for a cluster.
+func (c *Client) GetConnectionPool(ctx context.Context, clusterID int) (*ConnectionPool, error) {
+	var pool ConnectionPool
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/ha/%d/connection-pool", clusterID), nil, &pool)
+	if err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+// UpdateConnectionPool updates the connection pooler (PgBouncer) configuration for a cluster.
+func (c *Client) UpdateConnectionPool(ctx context.Context, clusterID int, req UpdateConnectionPoolRequest) (*ConnectionPool, error) {
+	var pool ConnectionPool
+	err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/ha/%d/connection-pool", clusterID), req, &pool)
+	if err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
@@ -7,15 +7,28 @@ import "time"
 
 // ProvisionClusterRequest is the request body for provisioning a new HA cluster.
 type ProvisionClusterRequest struct {
-	Name              string   `json:"name"`
-	Region            string   `json:"region"`
-	DBName            string   `json:"db_name,omitempty"`
-	DBType            string   `json:"db_type,omitempty"`
-	ServerType        string   `json:"server_type,omitempty"`
-	NodeCount         int      `json:"node_count,omitempty"`
-	PostgreSQLVersion int      `json:"postgresql_version,omitempty"`
-	Extensions        []string `json:"extensions,omitempty"`
-	SubscriptionID    *int     `json:"subscription_id,omitempty"`
+	Name               string              `json:"name"`
+	Region             string              `json:"region"`
+	DBName             string              `json:"db_name,omitempty"`
+	DBType             string              `json:"db_type,omitempty"`
+	ServerType         string              `json:"server_type,omitempty"`
+	NodeCount          int                 `json:"node_count,omitempty"`
+	PostgreSQLVersion  int                 `json:"postgresql_version,omitempty"`
+	Extensions         []string            `json:"extensions,omitempty"`
+	SubscriptionID     *int                `json:"subscription_id,omitempty"`
+	Source             *ClusterSource      `json:"source,omitempty"`
+	ReplicaOf          *int                `json:"replica_of,omitempty"`
+	MaintenanceWindow  *MaintenanceWindow  `json:"maintenance_window,omitempty"`
+	StorageGB          int                 `json:"storage_gb,omitempty"`
+	StorageAutoscaling *StorageAutoscaling `json:"storage_autoscaling,omitempty"`
+	RequireSSL         *bool               `json:"require_ssl,omitempty"`
+}
+
+// ClusterSource describes the cluster or backup a new cluster is restored from.
+type ClusterSource struct {
+	SourceClusterID int    `json:"source_cluster_id"`
+	BackupID        string `json:"backup_id,omitempty"`
+	PointInTime     string `json:"point_in_time,omitempty"`
 }
 
 // ProvisionClusterResponse is the response from provisioning a cluster.
@@ -30,32 +43,94 @@ type ProvisionClusterResponse struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+// UpdateClusterRequest is the request body for updating mutable cluster metadata.
+type UpdateClusterRequest struct {
+	Name               string              `json:"name,omitempty"`
+	MaintenanceWindow  *MaintenanceWindow  `json:"maintenance_window,omitempty"`
+	StorageAutoscaling *StorageAutoscaling `json:"storage_autoscaling,omitempty"`
+	RequireSSL         *bool               `json:"require_ssl,omitempty"`
+}
+
+// StorageAutoscaling configures automatic growth of a cluster's storage when
+// usage crosses a threshold.
+type StorageAutoscaling struct {
+	Enabled          bool `json:"enabled"`
+	ThresholdPercent int  `json:"threshold_percent,omitempty"`
+	MaxGB            int  `json:"max_gb,omitempty"`
+}
+
+// ResizeStorageRequest is the request body for growing a cluster's storage.
+type ResizeStorageRequest struct {
+	StorageGB int `json:"storage_gb"`
+}
+
+// ResizeStorageResponse is the response from growing a cluster's storage.
+type ResizeStorageResponse struct {
+	Message   string `json:"message"`
+	JobID     int    `json:"job_id"`
+	StreamURL string `json:"stream_url"`
+}
+
+// MaintenanceWindow is the weekly window in which Rivestack performs minor
+// upgrades and node replacements. StartHour is in UTC.
+type MaintenanceWindow struct {
+	DayOfWeek     string `json:"day_of_week"`
+	StartHour     int    `json:"start_hour"`
+	DurationHours int    `json:"duration_hours"`
+}
+
 // Cluster represents a full HA cluster with all its details.
 type Cluster struct {
-	ID                int                `json:"id"`
-	TenantID          string             `json:"tenant_id"`
-	Name              string             `json:"name"`
-	Region            string             `json:"region"`
-	DBType            string             `json:"db_type"`
-	ServerType        string             `json:"server_type"`
-	NodeCount         int                `json:"node_count"`
-	PostgreSQLVersion int                `json:"postgresql_version"`
-	DBName            string             `json:"db_name"`
-	DBUser            string             `json:"db_user"`
-	DBPassword        string             `json:"db_password"`
-	Host              string             `json:"host"`
-	ConnectionString  string             `json:"connection_string"`
-	Status            string             `json:"status"`
-	HealthStatus      string             `json:"health_status"`
-	SourceIPs         string             `json:"source_ips"`
-	ErrorMessage      string             `json:"error_message"`
-	CreatedAt         time.Time          `json:"created_at"`
-	UpdatedAt         time.Time          `json:"updated_at"`
-	Users             []ClusterUser      `json:"users"`
-	Databases         []ClusterDatabase  `json:"databases"`
-	Extensions        []ClusterExtension `json:"extensions"`
-	Grants            []ClusterGrant     `json:"grants"`
-	BackupConfig      *BackupConfig      `json:"backup_config"`
+	ID                       int                 `json:"id"`
+	TenantID                 string              `json:"tenant_id"`
+	Name                     string              `json:"name"`
+	Region                   string              `json:"region"`
+	DBType                   string              `json:"db_type"`
+	ServerType               string              `json:"server_type"`
+	NodeCount                int                 `json:"node_count"`
+	PostgreSQLVersion        int                 `json:"postgresql_version"`
+	DBName                   string              `json:"db_name"`
+	DBUser                   string              `json:"db_user"`
+	DBPassword               string              `json:"db_password"`
+	Host                     string              `json:"host"`
+	ConnectionString         string              `json:"connection_string"`
+	PooledConnectionString   string              `json:"pooled_connection_string"`
+	ReadOnlyHost             string              `json:"read_only_host"`
+	ReadOnlyConnectionString string              `json:"read_only_connection_string"`
+	Status                   string              `json:"status"`
+	HealthStatus             string              `json:"health_status"`
+	SourceIPs                string              `json:"source_ips"`
+	ErrorMessage             string              `json:"error_message"`
+	CreatedAt                time.Time           `json:"created_at"`
+	UpdatedAt                time.Time           `json:"updated_at"`
+	Users                    []ClusterUser       `json:"users"`
+	Databases                []ClusterDatabase   `json:"databases"`
+	Extensions               []ClusterExtension  `json:"extensions"`
+	Grants                   []ClusterGrant      `json:"grants"`
+	HBARules                 []ClusterHBARule    `json:"hba_rules"`
+	BackupConfig             *BackupConfig       `json:"backup_config"`
+	Nodes                    []ClusterNode       `json:"nodes"`
+	RestoredFrom             *ClusterSource      `json:"restored_from"`
+	ReplicaOf                *int                `json:"replica_of"`
+	ReplicationLag           int64               `json:"replication_lag"`
+	MaintenanceWindow        *MaintenanceWindow  `json:"maintenance_window"`
+	NextMaintenanceAt        *time.Time          `json:"next_maintenance_at"`
+	PendingMaintenance       []string            `json:"pending_maintenance"`
+	StorageGB                int                 `json:"storage_gb"`
+	StorageUsedGB            float64             `json:"storage_used_gb"`
+	StorageAutoscaling       *StorageAutoscaling `json:"storage_autoscaling"`
+	RequireSSL               bool                `json:"require_ssl"`
+	CACertificate            string              `json:"ca_certificate"`
+}
+
+// ClusterNode represents a single PostgreSQL node of a cluster.
+type ClusterNode struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+	Lag    int64  `json:"lag"`
+	IP     string `json:"ip"`
+	Host   string `json:"host"`
 }
 
 // ClusterUser represents a database user on a cluster.
@@ -76,13 +151,21 @@ type ClusterExtension struct {
 	Database  string `json:"database"`
 }
 
-// ClusterGrant represents an access grant on a cluster.
+// ClusterGrant represents an access grant on a cluster. ObjectType is one of
+// database, schema, table, sequence or function; an empty ObjectType is a
+// database-wide grant. An empty ObjectName covers all objects of the type in
+// Schema. Access is empty for grants with explicit Privileges.
 type ClusterGrant struct {
-	ID        int       `json:"id"`
-	Username  string    `json:"username"`
-	Database  string    `json:"database"`
-	Access    string    `json:"access"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              int       `json:"id"`
+	Username        string    `json:"username"`
+	Database        string    `json:"database"`
+	ObjectType      string    `json:"object_type"`
+	Schema          string    `json:"schema"`
+	ObjectName      string    `json:"object_name"`
+	Access          string    `json:"access"`
+	Privileges      []string  `json:"privileges"`
+	WithGrantOption bool      `json:"with_grant_option"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 // ClusterListResponse is the response from listing clusters.
@@ -90,6 +173,14 @@ type ClusterListResponse struct {
 	Clusters []Cluster `json:"clusters"`
 }
 
+// ClusterHBARule represents a pg_hba access rule on a cluster.
+type ClusterHBARule struct {
+	Username string `json:"username"`
+	Database string `json:"database"`
+	CIDR     string `json:"cidr"`
+	Method   string `json:"method"`
+}
+
 // ConfigureRequest is the request body for the unified configure endpoint.
 type ConfigureRequest struct {
 	Users           []ConfigUserRequest      `json:"users,omitempty"`
@@ -98,9 +189,12 @@ type ConfigureRequest struct {
 	DeleteDatabases []string                 `json:"delete_databases,omitempty"`
 	Extensions      []ConfigExtensionRequest `json:"extensions,omitempty"`
 	Grants          []ConfigGrantRequest     `json:"grants,omitempty"`
+	DeleteGrants    []ConfigGrantRequest     `json:"delete_grants,omitempty"`
 	SourceIPs       []string                 `json:"source_ips,omitempty"`
 	DeleteIPs       []string                 `json:"delete_ips,omitempty"`
 	ReplaceIPs      bool                     `json:"replace_ips,omitempty"`
+	HBARules        []ConfigHBARuleRequest   `json:"hba_rules,omitempty"`
+	DeleteHBARules  []ConfigHBARuleRequest   `json:"delete_hba_rules,omitempty"`
 }
 
 // ConfigUserRequest is a user creation request within ConfigureRequest.
@@ -120,11 +214,28 @@ type ConfigExtensionRequest struct {
 	Database  string `json:"database,omitempty"`
 }
 
-// ConfigGrantRequest is a grant creation request within ConfigureRequest.
+// ConfigGrantRequest is a grant creation or revocation request within
+// ConfigureRequest. Grants are identified by username, database, object
+// type, schema and object name; Access, Privileges and WithGrantOption are
+// ignored on revocation. Set either Access or Privileges.
 type ConfigGrantRequest struct {
+	Username        string   `json:"username"`
+	Database        string   `json:"database"`
+	ObjectType      string   `json:"object_type,omitempty"`
+	Schema          string   `json:"schema,omitempty"`
+	ObjectName      string   `json:"object_name,omitempty"`
+	Access          string   `json:"access,omitempty"`
+	Privileges      []string `json:"privileges,omitempty"`
+	WithGrantOption bool     `json:"with_grant_option,omitempty"`
+}
+
+// ConfigHBARuleRequest is a pg_hba rule within ConfigureRequest. Rules are
+// identified by username, database and CIDR; Method is ignored on deletion.
+type ConfigHBARuleRequest struct {
 	Username string `json:"username"`
 	Database string `json:"database"`
-	Access   string `json:"access,omitempty"`
+	CIDR     string `json:"cidr"`
+	Method   string `json:"method,omitempty"`
 }
 
 // ConfigureResponse is the response from the configure endpoint.
@@ -138,6 +249,7 @@ type ConfigureResponse struct {
 	DeletedDatabases []string             `json:"deleted_databases,omitempty"`
 	Extensions       []ConfigExtResponse  `json:"extensions,omitempty"`
 	Grants           []ConfigGrantRequest `json:"grants,omitempty"`
+	DeletedGrants    []ConfigGrantRequest `json:"deleted_grants,omitempty"`
 	SourceIPs        []string             `json:"source_ips,omitempty"`
 	DeletedIPs       []string             `json:"deleted_ips,omitempty"`
 }
@@ -169,6 +281,35 @@ type AddNodeResponse struct {
 	NewNodeName  string `json:"new_node_name"`
 }
 
+// PromoteClusterResponse is the response from promoting a read replica cluster.
+type PromoteClusterResponse struct {
+	Message   string `json:"message"`
+	JobID     int    `json:"job_id"`
+	StreamURL string `json:"stream_url"`
+}
+
+// ClusterStateResponse is the response from pausing or resuming a cluster.
+type ClusterStateResponse struct {
+	Message string `json:"message"`
+	JobID   int    `json:"job_id"`
+	Status  string `json:"status"`
+}
+
+// ConvertToHAResponse is the response from converting a core_solo cluster to HA.
+type ConvertToHAResponse struct {
+	Message   string `json:"message"`
+	JobID     int    `json:"job_id"`
+	StreamURL string `json:"stream_url"`
+}
+
+// RotatePasswordResponse is the response from rotating the default database user password.
+type RotatePasswordResponse struct {
+	Message    string `json:"message"`
+	JobID      int    `json:"job_id"`
+	StreamURL  string `json:"stream_url"`
+	DBPassword string `json:"db_password"`
+}
+
 // RemoveNodeRequest is the request body for removing a node.
 type RemoveNodeRequest struct {
 	NodeName           string `json:"node_name"`
@@ -185,6 +326,18 @@ type RemoveNodeResponse struct {
 	RemovedNode  string `json:"removed_node"`
 }
 
+// SwitchoverRequest is the request body for a controlled switchover.
+type SwitchoverRequest struct {
+	Candidate string `json:"candidate"`
+}
+
+// SwitchoverResponse is the response from initiating a switchover.
+type SwitchoverResponse struct {
+	Message   string `json:"message"`
+	JobID     int    `json:"job_id"`
+	StreamURL string `json:"stream_url"`
+}
+
 // BackupConfig represents the backup configuration for a cluster.
 type BackupConfig struct {
 	ID            int       `json:"id"`
@@ -202,6 +355,81 @@ type UpdateBackupConfigRequest struct {
 	RetentionFull *int   `json:"retention_full,omitempty"`
 }
 
+// ConnectionPool represents the connection pooler (PgBouncer) configuration for a cluster.
+type ConnectionPool struct {
+	ID                     int                      `json:"id"`
+	ClusterID              int                      `json:"cluster_id"`
+	Enabled                bool                     `json:"enabled"`
+	PoolMode               string                   `json:"pool_mode"`
+	DefaultPoolSize        int                      `json:"default_pool_size"`
+	MaxClientConn          int                      `json:"max_client_conn"`
+	Databases              []ConnectionPoolDatabase `json:"databases"`
+	Host                   string                   `json:"host"`
+	Port                   int                      `json:"port"`
+	PooledConnectionString string                   `json:"pooled_connection_string"`
+	UpdatedAt              time.Time                `json:"updated_at"`
+}
+
+// ConnectionPoolDatabase overrides pool settings for a single database.
+type ConnectionPoolDatabase struct {
+	Database string `json:"database"`
+	PoolMode string `json:"pool_mode,omitempty"`
+	PoolSize int    `json:"pool_size,omitempty"`
+}
+
+// UpdateConnectionPoolRequest is the request body for updating the connection pooler.
+// Databases replaces the full list of per-database pools.
+type UpdateConnectionPoolRequest struct {
+	Enabled         *bool                    `json:"enabled,omitempty"`
+	PoolMode        string                   `json:"pool_mode,omitempty"`
+	DefaultPoolSize *int                     `json:"default_pool_size,omitempty"`
+	MaxClientConn   *int                     `json:"max_client_conn,omitempty"`
+	Databases       []ConnectionPoolDatabase `json:"databases"`
+}
+
+// ParameterDefinition describes a tunable PostgreSQL server parameter.
+// Type is one of integer, real, bool, enum, or string. Integer and real
+// values are given in the parameter's base unit.
+type ParameterDefinition struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Unit            string   `json:"unit"`
+	Min             *float64 `json:"min"`
+	Max             *float64 `json:"max"`
+	EnumValues      []string `json:"enum_values"`
+	RequiresRestart bool     `json:"requires_restart"`
+	Description     string   `json:"description"`
+}
+
+// ParameterCatalogResponse is the response from listing tunable parameters.
+type ParameterCatalogResponse struct {
+	Parameters []ParameterDefinition `json:"parameters"`
+}
+
+// ClusterParameters represents the server parameters overridden on a cluster.
+type ClusterParameters struct {
+	ClusterID      int               `json:"cluster_id"`
+	Parameters     map[string]string `json:"parameters"`
+	PendingRestart bool              `json:"pending_restart"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// UpdateClusterParametersRequest is the request body for updating server parameters.
+// Reset lists parameters to return to their defaults.
+type UpdateClusterParametersRequest struct {
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	Reset          []string          `json:"reset,omitempty"`
+	RollingRestart bool              `json:"rolling_restart"`
+}
+
+// UpdateClusterParametersResponse is the response from updating server parameters.
+type UpdateClusterParametersResponse struct {
+	Message         string `json:"message"`
+	JobID           int    `json:"job_id"`
+	StreamURL       string `json:"stream_url"`
+	RestartRequired bool   `json:"restart_required"`
+}
+
 // ServerType represents an available server type.
 type ServerType struct {
 	Type           string  `json:"type"`
@@ -220,6 +448,18 @@ type ServerTypesResponse struct {
 	Default     string       `json:"default"`
 }
 
+// Region represents a region clusters can be provisioned in.
+type Region struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RegionsResponse is the response from listing regions.
+type RegionsResponse struct {
+	Regions []Region `json:"regions"`
+	Default string   `json:"default"`
+}
+
 // Extension represents an available PostgreSQL extension.
 type Extension struct {
 	Name        string `json:"name"`
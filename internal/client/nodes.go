@@ -18,13 +18,20 @@ func (c *Client) AddNode(ctx context.Context, clusterID int) (*AddNodeResponse,
 	return &resp, nil
 }
 
-// RemoveNode removes a node from the cluster.
-func (c *Client) RemoveNode(ctx context.Context, clusterID int, nodeName string) (*RemoveNodeResponse, error) {
-	req := RemoveNodeRequest{
-		NodeName:           nodeName,
-		DeleteServer:       true,
-		RemovePostgresData: true,
+// ConvertToHA enables replication on a core_solo cluster so it can run as an
+// HA cluster. Replica nodes are added separately with AddNode.
+func (c *Client) ConvertToHA(ctx context.Context, clusterID int) (*ConvertToHAResponse, error) {
+	var resp ConvertToHAResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/convert-to-ha", clusterID), nil, &resp)
+	if err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
+
+// RemoveNode removes a node from the cluster. The request controls whether the
+// node's server and PostgreSQL data are destroyed along with it.
+func (c *Client) RemoveNode(ctx context.Context, clusterID int, req RemoveNodeRequest) (*RemoveNodeResponse, error) {
 	var resp RemoveNodeResponse
 	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/remove-node", clusterID), req, &resp)
 	if err != nil {
@@ -32,3 +39,14 @@ func (c *Client) RemoveNode(ctx context.Context, clusterID int, nodeName string)
 	}
 	return &resp, nil
 }
+
+// Switchover moves the primary role of the cluster to the given replica node.
+func (c *Client) Switchover(ctx context.Context, clusterID int, targetNode string) (*SwitchoverResponse, error) {
+	req := SwitchoverRequest{Candidate: targetNode}
+	var resp SwitchoverResponse
+	err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/ha/%d/switchover", clusterID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
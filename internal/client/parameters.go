@@ -0,0 +1,187 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// memoryUnits and timeUnits are the unit suffixes PostgreSQL accepts for
+// memory and time parameters, in bytes and microseconds.
+var (
+	memoryUnits = map[string]float64{
+		"B":  1,
+		"kB": 1 << 10,
+		"MB": 1 << 20,
+		"GB": 1 << 30,
+		"TB": 1 << 40,
+	}
+	timeUnits = map[string]float64{
+		"us":  1,
+		"ms":  1e3,
+		"s":   1e6,
+		"min": 60e6,
+		"h":   3600e6,
+		"d":   86400e6,
+	}
+)
+
+// GetParameterCatalog retrieves the PostgreSQL server parameters that can be tuned.
+func (c *Client) GetParameterCatalog(ctx context.Context) (*ParameterCatalogResponse, error) {
+	var resp ParameterCatalogResponse
+	err := c.doRequest(ctx, "GET", "/api/ha/parameters", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetClusterParameters retrieves the PostgreSQL server parameters overridden on a cluster.
+func (c *Client) GetClusterParameters(ctx context.Context, clusterID int) (*ClusterParameters, error) {
+	var params ClusterParameters
+	err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/ha/%d/parameters", clusterID), nil, &params)
+	if err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// UpdateClusterParameters sets or resets PostgreSQL server parameters on a cluster.
+// The change is applied by a cluster job.
+func (c *Client) UpdateClusterParameters(ctx context.Context, clusterID int, req UpdateClusterParametersRequest) (*UpdateClusterParametersResponse, error) {
+	var resp UpdateClusterParametersResponse
+	err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/ha/%d/parameters", clusterID), req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Validate checks a value against the parameter's type and allowed range.
+func (d ParameterDefinition) Validate(value string) error {
+	switch d.Type {
+	case "integer", "real":
+		n, err := d.parseNumber(value)
+		if err != nil {
+			return err
+		}
+		if d.Min != nil && n < *d.Min {
+			return fmt.Errorf("%s must be at least %s", d.Name, strconv.FormatFloat(*d.Min, 'f', -1, 64))
+		}
+		if d.Max != nil && n > *d.Max {
+			return fmt.Errorf("%s must be at most %s", d.Name, strconv.FormatFloat(*d.Max, 'f', -1, 64))
+		}
+	case "bool":
+		if _, ok := parseBool(value); !ok {
+			return fmt.Errorf("%s must be a boolean (on or off)", d.Name)
+		}
+	case "enum":
+		for _, v := range d.EnumValues {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of: %s", d.Name, strings.Join(d.EnumValues, ", "))
+	}
+	return nil
+}
+
+// Equal reports whether two values set the parameter to the same setting,
+// e.g. "64MB" and "65536" for a parameter measured in kB.
+func (d ParameterDefinition) Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	switch d.Type {
+	case "integer", "real":
+		x, errA := d.parseNumber(a)
+		y, errB := d.parseNumber(b)
+		return errA == nil && errB == nil && x == y
+	case "bool":
+		x, okA := parseBool(a)
+		y, okB := parseBool(b)
+		return okA && okB && x == y
+	}
+	return false
+}
+
+// parseNumber parses a numeric value, with an optional unit suffix, into the
+// parameter's base unit. Like PostgreSQL, integer values given with a unit
+// are rounded to the nearest base unit.
+func (d ParameterDefinition) parseNumber(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	end := len(value)
+	for end > 0 && (value[end-1] < '0' || value[end-1] > '9') && value[end-1] != '.' {
+		end--
+	}
+	number, suffix := value[:end], strings.TrimSpace(value[end:])
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be %s %s", d.Name, article(d.Type), d.Type)
+	}
+	if suffix == "" {
+		if d.Type == "integer" && n != math.Trunc(n) {
+			return 0, fmt.Errorf("%s must be %s %s", d.Name, article(d.Type), d.Type)
+		}
+		return n, nil
+	}
+
+	scale, err := d.unitScale(suffix)
+	if err != nil {
+		return 0, err
+	}
+	n *= scale
+	if d.Type == "integer" {
+		n = math.Round(n)
+	}
+	return n, nil
+}
+
+// unitScale returns how many of the parameter's base units one suffix unit
+// is worth. Base units may carry a multiplier, such as 8kB.
+func (d ParameterDefinition) unitScale(suffix string) (float64, error) {
+	base := strings.TrimLeft(d.Unit, "0123456789")
+	multiplier := 1.0
+	if prefix := d.Unit[:len(d.Unit)-len(base)]; prefix != "" {
+		multiplier, _ = strconv.ParseFloat(prefix, 64)
+	}
+
+	for _, units := range []map[string]float64{memoryUnits, timeUnits} {
+		baseSize, ok := units[base]
+		if !ok {
+			continue
+		}
+		size, ok := units[suffix]
+		if !ok {
+			break
+		}
+		return size / (baseSize * multiplier), nil
+	}
+	if d.Unit == "" {
+		return 0, fmt.Errorf("%s does not take a unit", d.Name)
+	}
+	return 0, fmt.Errorf("%s has an invalid unit %q for a value measured in %s", d.Name, suffix, d.Unit)
+}
+
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "on", "true", "yes", "1":
+		return true, true
+	case "off", "false", "no", "0":
+		return false, true
+	}
+	return false, false
+}
+
+func article(word string) string {
+	if strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,16 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import "context"
+
+// GetRegions retrieves the regions clusters can be provisioned in.
+func (c *Client) GetRegions(ctx context.Context) (*RegionsResponse, error) {
+	var resp RegionsResponse
+	err := c.doRequest(ctx, "GET", "/api/ha/regions", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -16,10 +16,14 @@ import (
 	"github.com/rivestack/terraform-provider-rivestack/internal/client"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_backup_config"
+	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_config"
+	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_connection_pool"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_database"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_extension"
-
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_grant"
+	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_hba_rule"
+	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_parameters"
+	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_switchover"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/cluster_user"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/extensions"
 	"github.com/rivestack/terraform-provider-rivestack/internal/resources/server_types"
@@ -113,6 +117,11 @@ func (p *RivestackProvider) Resources(_ context.Context) []func() resource.Resou
 		cluster_extension.NewResource,
 		cluster_grant.NewResource,
 		cluster_backup_config.NewResource,
+		cluster_connection_pool.NewResource,
+		cluster_switchover.NewResource,
+		cluster_parameters.NewResource,
+		cluster_hba_rule.NewResource,
+		cluster_config.NewResource,
 	}
 }
 
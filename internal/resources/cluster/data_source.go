@@ -28,23 +28,29 @@ type clusterDataSource struct {
 }
 
 type clusterDataSourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	TenantID          types.String `tfsdk:"tenant_id"`
-	Region            types.String `tfsdk:"region"`
-	DBType            types.String `tfsdk:"db_type"`
-	ServerType        types.String `tfsdk:"server_type"`
-	NodeCount         types.Int64  `tfsdk:"node_count"`
-	PostgreSQLVersion types.Int64  `tfsdk:"postgresql_version"`
-	DBName            types.String `tfsdk:"db_name"`
-	DBUser            types.String `tfsdk:"db_user"`
-	DBPassword        types.String `tfsdk:"db_password"`
-	Host              types.String `tfsdk:"host"`
-	ConnectionString  types.String `tfsdk:"connection_string"`
-	Status            types.String `tfsdk:"status"`
-	HealthStatus      types.String `tfsdk:"health_status"`
-	CreatedAt         types.String `tfsdk:"created_at"`
-	UpdatedAt         types.String `tfsdk:"updated_at"`
+	ID                       types.String `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	TenantID                 types.String `tfsdk:"tenant_id"`
+	Region                   types.String `tfsdk:"region"`
+	DBType                   types.String `tfsdk:"db_type"`
+	ServerType               types.String `tfsdk:"server_type"`
+	NodeCount                types.Int64  `tfsdk:"node_count"`
+	PostgreSQLVersion        types.Int64  `tfsdk:"postgresql_version"`
+	DBName                   types.String `tfsdk:"db_name"`
+	DBUser                   types.String `tfsdk:"db_user"`
+	DBPassword               types.String `tfsdk:"db_password"`
+	Host                     types.String `tfsdk:"host"`
+	ConnectionString         types.String `tfsdk:"connection_string"`
+	PooledConnectionString   types.String `tfsdk:"pooled_connection_string"`
+	ReadOnlyHost             types.String `tfsdk:"read_only_host"`
+	ReadOnlyConnectionString types.String `tfsdk:"read_only_connection_string"`
+	Nodes                    types.List   `tfsdk:"nodes"`
+	RequireSSL               types.Bool   `tfsdk:"require_ssl"`
+	CACertificate            types.String `tfsdk:"ca_certificate"`
+	Status                   types.String `tfsdk:"status"`
+	HealthStatus             types.String `tfsdk:"health_status"`
+	CreatedAt                types.String `tfsdk:"created_at"`
+	UpdatedAt                types.String `tfsdk:"updated_at"`
 }
 
 func (d *clusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -105,10 +111,64 @@ func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:    true,
 			},
 			"connection_string": schema.StringAttribute{
-				Description: "Full PostgreSQL connection string.",
+				Description: "Full PostgreSQL connection string, including the sslmode matching require_ssl.",
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"require_ssl": schema.BoolAttribute{
+				Description: "Whether connections without SSL are rejected.",
+				Computed:    true,
+			},
+			"ca_certificate": schema.StringAttribute{
+				Description: "PEM-encoded CA certificate of the cluster, for clients that verify the server (sslmode=verify-full).",
+				Computed:    true,
+			},
+			"pooled_connection_string": schema.StringAttribute{
+				Description: "PostgreSQL connection string through the built-in connection pooler.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"read_only_host": schema.StringAttribute{
+				Description: "Hostname that balances read-only connections across the replicas.",
+				Computed:    true,
+			},
+			"read_only_connection_string": schema.StringAttribute{
+				Description: "PostgreSQL connection string for read-only connections to the replicas.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "PostgreSQL nodes of the cluster.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Node name.",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Node role: leader or replica.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Node status.",
+							Computed:    true,
+						},
+						"lag": schema.Int64Attribute{
+							Description: "Replication lag in bytes (0 for the leader).",
+							Computed:    true,
+						},
+						"ip": schema.StringAttribute{
+							Description: "Node IP address.",
+							Computed:    true,
+						},
+						"host": schema.StringAttribute{
+							Description: "Hostname for direct connections to this node.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"status": schema.StringAttribute{
 				Description: "Cluster status.",
 				Computed:    true,
@@ -175,7 +235,13 @@ func (d *clusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	state.DBUser = types.StringValue(cluster.DBUser)
 	state.DBPassword = types.StringValue(cluster.DBPassword)
 	state.Host = types.StringValue(cluster.Host)
-	state.ConnectionString = types.StringValue(cluster.ConnectionString)
+	state.ConnectionString = types.StringValue(client.WithSSLMode(cluster.ConnectionString, cluster.RequireSSL))
+	state.PooledConnectionString = types.StringValue(client.WithSSLMode(cluster.PooledConnectionString, cluster.RequireSSL))
+	state.ReadOnlyHost = types.StringValue(cluster.ReadOnlyHost)
+	state.ReadOnlyConnectionString = types.StringValue(client.WithSSLMode(cluster.ReadOnlyConnectionString, cluster.RequireSSL))
+	state.RequireSSL = types.BoolValue(cluster.RequireSSL)
+	state.CACertificate = types.StringValue(cluster.CACertificate)
+	state.Nodes = nodesToList(cluster.Nodes)
 	state.Status = types.StringValue(cluster.Status)
 	state.HealthStatus = types.StringValue(cluster.HealthStatus)
 	state.CreatedAt = types.StringValue(cluster.CreatedAt.Format(time.RFC3339))
@@ -6,32 +6,46 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/rivestack/terraform-provider-rivestack/internal/client"
 )
 
 var (
-	_ resource.Resource                = &clusterResource{}
-	_ resource.ResourceWithImportState = &clusterResource{}
+	_ resource.Resource                   = &clusterResource{}
+	_ resource.ResourceWithImportState    = &clusterResource{}
+	_ resource.ResourceWithModifyPlan     = &clusterResource{}
+	_ resource.ResourceWithValidateConfig = &clusterResource{}
 )
 
+// bootstrapPendingKey is the private state key recording that the bootstrap
+// block has not been applied yet.
+const bootstrapPendingKey = "bootstrap_pending"
+
 // NewResource returns a new cluster resource.
 func NewResource() resource.Resource {
 	return &clusterResource{}
@@ -42,24 +56,127 @@ type clusterResource struct {
 }
 
 type clusterResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Region            types.String `tfsdk:"region"`
-	ServerType        types.String `tfsdk:"server_type"`
-	NodeCount         types.Int64  `tfsdk:"node_count"`
-	DBName            types.String `tfsdk:"db_name"`
-	DBType            types.String `tfsdk:"db_type"`
-	PostgreSQLVersion types.Int64  `tfsdk:"postgresql_version"`
-	Extensions        types.List   `tfsdk:"extensions"`
-	SubscriptionID    types.Int64  `tfsdk:"subscription_id"`
-	TenantID          types.String `tfsdk:"tenant_id"`
-	Status            types.String `tfsdk:"status"`
-	Host              types.String `tfsdk:"host"`
-	ConnectionString  types.String `tfsdk:"connection_string"`
-	DBUser            types.String `tfsdk:"db_user"`
-	DBPassword        types.String `tfsdk:"db_password"`
-	CreatedAt         types.String `tfsdk:"created_at"`
-	UpdatedAt         types.String `tfsdk:"updated_at"`
+	ID                        types.String  `tfsdk:"id"`
+	Name                      types.String  `tfsdk:"name"`
+	Region                    types.String  `tfsdk:"region"`
+	ServerType                types.String  `tfsdk:"server_type"`
+	NodeCount                 types.Int64   `tfsdk:"node_count"`
+	DBName                    types.String  `tfsdk:"db_name"`
+	DBType                    types.String  `tfsdk:"db_type"`
+	PostgreSQLVersion         types.Int64   `tfsdk:"postgresql_version"`
+	Extensions                types.List    `tfsdk:"extensions"`
+	SubscriptionID            types.Int64   `tfsdk:"subscription_id"`
+	TenantID                  types.String  `tfsdk:"tenant_id"`
+	State                     types.String  `tfsdk:"state"`
+	Status                    types.String  `tfsdk:"status"`
+	HealthStatus              types.String  `tfsdk:"health_status"`
+	ReplaceIfFailed           types.Bool    `tfsdk:"replace_if_failed"`
+	EstimatedMonthlyCost      types.Float64 `tfsdk:"estimated_monthly_cost"`
+	Host                      types.String  `tfsdk:"host"`
+	ConnectionString          types.String  `tfsdk:"connection_string"`
+	PooledConnectionString    types.String  `tfsdk:"pooled_connection_string"`
+	ReadOnlyHost              types.String  `tfsdk:"read_only_host"`
+	ReadOnlyConnectionString  types.String  `tfsdk:"read_only_connection_string"`
+	RequireSSL                types.Bool    `tfsdk:"require_ssl"`
+	CACertificate             types.String  `tfsdk:"ca_certificate"`
+	DBUser                    types.String  `tfsdk:"db_user"`
+	DBPassword                types.String  `tfsdk:"db_password"`
+	PasswordRotationTrigger   types.String  `tfsdk:"password_rotation_trigger"`
+	CreatedAt                 types.String  `tfsdk:"created_at"`
+	UpdatedAt                 types.String  `tfsdk:"updated_at"`
+	Nodes                     types.List    `tfsdk:"nodes"`
+	ScaleDownPolicy           types.Object  `tfsdk:"scale_down_policy"`
+	Source                    types.Object  `tfsdk:"source"`
+	RestoredFrom              types.Object  `tfsdk:"restored_from"`
+	ReplicaOf                 types.String  `tfsdk:"replica_of"`
+	ReplicationLag            types.Int64   `tfsdk:"replication_lag"`
+	MaintenanceWindow         types.Object  `tfsdk:"maintenance_window"`
+	NextMaintenanceAt         types.String  `tfsdk:"next_maintenance_at"`
+	PendingMaintenanceActions types.List    `tfsdk:"pending_maintenance_actions"`
+	StorageGB                 types.Int64   `tfsdk:"storage_gb"`
+	StorageUsedGB             types.Float64 `tfsdk:"storage_used_gb"`
+	StorageAutoscaling        types.Object  `tfsdk:"storage_autoscaling"`
+	Bootstrap                 types.Object  `tfsdk:"bootstrap"`
+	BootstrapPasswords        types.Map     `tfsdk:"bootstrap_passwords"`
+}
+
+type bootstrapModel struct {
+	Users        types.List `tfsdk:"users"`
+	Databases    types.List `tfsdk:"databases"`
+	Grants       types.List `tfsdk:"grants"`
+	Extensions   types.List `tfsdk:"extensions"`
+	AllowedCIDRs types.List `tfsdk:"allowed_cidrs"`
+}
+
+type bootstrapDatabaseModel struct {
+	Name  types.String `tfsdk:"name"`
+	Owner types.String `tfsdk:"owner"`
+}
+
+type bootstrapGrantModel struct {
+	Username types.String `tfsdk:"username"`
+	Database types.String `tfsdk:"database"`
+	Access   types.String `tfsdk:"access"`
+}
+
+type bootstrapExtensionModel struct {
+	Extension types.String `tfsdk:"extension"`
+	Database  types.String `tfsdk:"database"`
+}
+
+type storageAutoscalingModel struct {
+	ThresholdPercent types.Int64 `tfsdk:"threshold_percent"`
+	MaxGB            types.Int64 `tfsdk:"max_gb"`
+}
+
+var storageAutoscalingAttrTypes = map[string]attr.Type{
+	"threshold_percent": types.Int64Type,
+	"max_gb":            types.Int64Type,
+}
+
+type maintenanceWindowModel struct {
+	DayOfWeek     types.String `tfsdk:"day_of_week"`
+	StartHour     types.Int64  `tfsdk:"start_hour"`
+	DurationHours types.Int64  `tfsdk:"duration_hours"`
+}
+
+var maintenanceWindowAttrTypes = map[string]attr.Type{
+	"day_of_week":    types.StringType,
+	"start_hour":     types.Int64Type,
+	"duration_hours": types.Int64Type,
+}
+
+type clusterSourceModel struct {
+	SourceClusterID types.String `tfsdk:"source_cluster_id"`
+	BackupID        types.String `tfsdk:"backup_id"`
+	PointInTime     types.String `tfsdk:"point_in_time"`
+}
+
+var sourceAttrTypes = map[string]attr.Type{
+	"source_cluster_id": types.StringType,
+	"backup_id":         types.StringType,
+	"point_in_time":     types.StringType,
+}
+
+type scaleDownPolicyModel struct {
+	DeleteServer       types.Bool `tfsdk:"delete_server"`
+	RemovePostgresData types.Bool `tfsdk:"remove_postgres_data"`
+}
+
+// Offline fallbacks used when the region and server type catalogs cannot be
+// fetched from the API.
+var (
+	fallbackRegions     = []string{"eu-central", "us-east"}
+	fallbackServerTypes = []string{"starter", "growth", "scale"}
+)
+
+var nodeAttrTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"role":   types.StringType,
+	"status": types.StringType,
+	"lag":    types.Int64Type,
+	"ip":     types.StringType,
+	"host":   types.StringType,
 }
 
 func (r *clusterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -78,36 +195,142 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Display name for the cluster.",
+				Description: "Display name for the cluster. Can be changed in place.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"region": schema.StringAttribute{
-				Description: "Region for the cluster (e.g., eu-central, us-east).",
+				Description: "Region for the cluster (e.g., eu-central, us-east). Validated against the live region catalog at plan time.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Validators: []validator.String{
-					stringvalidator.OneOf("eu-central", "us-east"),
-				},
 			},
 			"server_type": schema.StringAttribute{
-				Description: "Server size: starter, growth, or scale.",
+				Description: "Server size (e.g., starter, growth, scale). Validated against the live server type catalog at plan time.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("starter"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Validators: []validator.String{
-					stringvalidator.OneOf("starter", "growth", "scale"),
+			},
+			"storage_gb": schema.Int64Attribute{
+				Description: "Storage per node in GB. Defaults to the storage of the server type. Can be increased in place but never reduced.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_used_gb": schema.Float64Attribute{
+				Description: "Storage currently used on the leader node in GB.",
+				Computed:    true,
+			},
+			"storage_autoscaling": schema.SingleNestedAttribute{
+				Description: "Grow storage automatically when usage crosses a threshold. Growth beyond the configured storage_gb is not reported as drift.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"threshold_percent": schema.Int64Attribute{
+						Description: "Usage percentage that triggers growth (50-95). Defaults to 80.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(80),
+						Validators: []validator.Int64{
+							int64validator.Between(50, 95),
+						},
+					},
+					"max_gb": schema.Int64Attribute{
+						Description: "Upper limit for automatic growth in GB.",
+						Required:    true,
+					},
+				},
+			},
+			"bootstrap": schema.SingleNestedAttribute{
+				Description: "Users, databases, grants, extensions and allowed CIDRs to create in a single configure job right after the cluster becomes active. " +
+					"If that does not complete, for example because the apply stopped waiting for the cluster, the next apply retries it. " +
+					"Once applied, later changes are recorded in state but not applied. Use the rivestack_cluster_* resources to manage access objects afterwards.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"users": schema.ListAttribute{
+						Description: "Usernames to create. Generated passwords are exported in bootstrap_passwords.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"databases": schema.ListNestedAttribute{
+						Description: "Databases to create.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Description: "Database name.",
+									Required:    true,
+								},
+								"owner": schema.StringAttribute{
+									Description: "Owner of the database.",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"grants": schema.ListNestedAttribute{
+						Description: "Access grants to create.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"username": schema.StringAttribute{
+									Description: "Username to grant access to.",
+									Required:    true,
+								},
+								"database": schema.StringAttribute{
+									Description: "Database to grant access on.",
+									Required:    true,
+								},
+								"access": schema.StringAttribute{
+									Description: "Access level: read or write. Defaults to write.",
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString("write"),
+									Validators: []validator.String{
+										stringvalidator.OneOf("read", "write"),
+									},
+								},
+							},
+						},
+					},
+					"extensions": schema.ListNestedAttribute{
+						Description: "Extensions to install.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"extension": schema.StringAttribute{
+									Description: "Extension name.",
+									Required:    true,
+								},
+								"database": schema.StringAttribute{
+									Description: "Database to install the extension on. Defaults to db_name.",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"allowed_cidrs": schema.ListAttribute{
+						Description: "Source IP ranges to add to the cluster's allowlist.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"bootstrap_passwords": schema.MapAttribute{
+				Description: "Generated passwords of the bootstrap users, keyed by username.",
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"node_count": schema.Int64Attribute{
-				Description: "Number of nodes (1-3).",
+				Description: "Number of nodes (1-3). Must be 1 for core_solo clusters, which is also the default for that type.",
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(2),
@@ -125,12 +348,18 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"db_type": schema.StringAttribute{
-				Description: "Cluster type: ha or core_solo.",
+				Description: "Cluster type: ha or core_solo. A core_solo cluster can be converted to ha in place; any other change forces a new cluster.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("ha"),
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !(req.StateValue.ValueString() == "core_solo" && req.PlanValue.ValueString() == "ha")
+						},
+						"Changing db_type forces a new cluster unless a core_solo cluster is converted to ha.",
+						"Changing `db_type` forces a new cluster unless a `core_solo` cluster is converted to `ha`.",
+					),
 				},
 				Validators: []validator.String{
 					stringvalidator.OneOf("ha", "core_solo"),
@@ -146,12 +375,135 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"extensions": schema.ListAttribute{
-				Description: "Additional PostgreSQL extensions to install at creation time.",
+				Description: "Additional PostgreSQL extensions to install on the default database. New entries are installed in place; removing an entry does not uninstall the extension.",
 				Optional:    true,
 				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.RequiresReplace(),
+			},
+			"scale_down_policy": schema.SingleNestedAttribute{
+				Description: "Controls what happens to a node's server and data when node_count is reduced. Defaults to deleting both.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"delete_server": schema.BoolAttribute{
+						Description: "Delete the server of a removed node.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"remove_postgres_data": schema.BoolAttribute{
+						Description: "Remove the PostgreSQL data directory of a removed node.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+				},
+			},
+			"source": schema.SingleNestedAttribute{
+				Description: "Restore the new cluster from another cluster's backups instead of starting empty. Changing this forces a new cluster.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"source_cluster_id": schema.StringAttribute{
+						Description: "ID of the cluster to restore from.",
+						Required:    true,
+					},
+					"backup_id": schema.StringAttribute{
+						Description: "ID of the backup to restore. Defaults to the latest backup.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("point_in_time")),
+						},
+					},
+					"point_in_time": schema.StringAttribute{
+						Description: "RFC 3339 timestamp to recover to (e.g., 2026-01-01T03:00:00Z).",
+						Optional:    true,
+					},
+				},
+			},
+			"restored_from": schema.SingleNestedAttribute{
+				Description: "Where the cluster was restored from, if it was created from a source.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
 				},
+				Attributes: map[string]schema.Attribute{
+					"source_cluster_id": schema.StringAttribute{
+						Description: "ID of the source cluster.",
+						Computed:    true,
+					},
+					"backup_id": schema.StringAttribute{
+						Description: "ID of the restored backup.",
+						Computed:    true,
+					},
+					"point_in_time": schema.StringAttribute{
+						Description: "Point in time the cluster was recovered to.",
+						Computed:    true,
+					},
+				},
+			},
+			"replica_of": schema.StringAttribute{
+				Description: "ID of a cluster to create this cluster as a streaming read replica of, typically in another region. Removing this attribute promotes the replica to a standalone primary in place; setting or changing it forces a new cluster.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// Promotion (removing replica_of) is done in place.
+							resp.RequiresReplace = !req.PlanValue.IsNull()
+						},
+						"Changing replica_of forces a new cluster unless it is removed to promote the replica.",
+						"Changing `replica_of` forces a new cluster unless it is removed to promote the replica.",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("source")),
+				},
+			},
+			"replication_lag": schema.Int64Attribute{
+				Description: "Replication lag behind the source cluster in bytes. Null when the cluster is not a read replica.",
+				Computed:    true,
+			},
+			"maintenance_window": schema.SingleNestedAttribute{
+				Description: "Weekly window for minor upgrades and node replacements. Defaults to the window assigned by Rivestack. Can be changed in place.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"day_of_week": schema.StringAttribute{
+						Description: "Day of the week (monday-sunday).",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"),
+						},
+					},
+					"start_hour": schema.Int64Attribute{
+						Description: "Hour of the day the window starts, in UTC (0-23).",
+						Required:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 23),
+						},
+					},
+					"duration_hours": schema.Int64Attribute{
+						Description: "Length of the window in hours (1-12). Defaults to 4.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(4),
+						Validators: []validator.Int64{
+							int64validator.Between(1, 12),
+						},
+					},
+				},
+			},
+			"next_maintenance_at": schema.StringAttribute{
+				Description: "Start of the next scheduled maintenance, if any.",
+				Computed:    true,
+			},
+			"pending_maintenance_actions": schema.ListAttribute{
+				Description: "Maintenance actions that will run in the next window.",
+				Computed:    true,
+				ElementType: types.StringType,
 			},
 			"subscription_id": schema.Int64Attribute{
 				Description: "Pool subscription ID to draw nodes from.",
@@ -167,16 +519,68 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"state": schema.StringAttribute{
+				Description: "Desired power state: running or paused. A paused cluster stops its nodes to save cost and keeps its data. Defaults to running.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("running"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("running", "paused"),
+				},
+			},
 			"status": schema.StringAttribute{
 				Description: "Cluster status.",
 				Computed:    true,
 			},
+			"health_status": schema.StringAttribute{
+				Description: "Cluster health status.",
+				Computed:    true,
+			},
+			"replace_if_failed": schema.BoolAttribute{
+				Description: "Plan replacement of the cluster when it is found in the failed state. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"estimated_monthly_cost": schema.Float64Attribute{
+				Description: "Estimated monthly cost of the cluster (price per node of the server type times node_count). Null when pricing is unavailable.",
+				Computed:    true,
+			},
 			"host": schema.StringAttribute{
 				Description: "Cluster hostname for connections.",
 				Computed:    true,
 			},
+			"require_ssl": schema.BoolAttribute{
+				Description: "Reject connections that do not use SSL. Defaults to the Rivestack setting for new clusters. Can be changed in place.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_certificate": schema.StringAttribute{
+				Description: "PEM-encoded CA certificate of the cluster, for clients that verify the server (sslmode=verify-full).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"connection_string": schema.StringAttribute{
-				Description: "Full PostgreSQL connection string.",
+				Description: "Full PostgreSQL connection string, including the sslmode matching require_ssl.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"pooled_connection_string": schema.StringAttribute{
+				Description: "PostgreSQL connection string through the built-in connection pooler. Empty unless a rivestack_cluster_connection_pool is enabled.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"read_only_host": schema.StringAttribute{
+				Description: "Hostname that balances read-only connections across the replicas.",
+				Computed:    true,
+			},
+			"read_only_connection_string": schema.StringAttribute{
+				Description: "PostgreSQL connection string for read-only connections to the replicas.",
 				Computed:    true,
 				Sensitive:   true,
 			},
@@ -192,6 +596,10 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"password_rotation_trigger": schema.StringAttribute{
+				Description: "Arbitrary value that rotates db_password when changed (e.g., a date). The cluster is not otherwise modified.",
+				Optional:    true,
+			},
 			"created_at": schema.StringAttribute{
 				Description: "Cluster creation timestamp.",
 				Computed:    true,
@@ -203,6 +611,38 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Cluster last update timestamp.",
 				Computed:    true,
 			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "PostgreSQL nodes of the cluster and their current roles.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Node name.",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Node role: leader or replica.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Node status.",
+							Computed:    true,
+						},
+						"lag": schema.Int64Attribute{
+							Description: "Replication lag in bytes (0 for the leader).",
+							Computed:    true,
+						},
+						"ip": schema.StringAttribute{
+							Description: "Node IP address.",
+							Computed:    true,
+						},
+						"host": schema.StringAttribute{
+							Description: "Hostname for direct connections to this node.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -251,6 +691,59 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 		provisionReq.Extensions = exts
 	}
 
+	if !plan.Source.IsNull() && !plan.Source.IsUnknown() {
+		var source clusterSourceModel
+		resp.Diagnostics.Append(plan.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sourceID, err := strconv.Atoi(source.SourceClusterID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source").AtName("source_cluster_id"), "Invalid source cluster ID",
+				fmt.Sprintf("Could not parse source cluster ID %q: %s", source.SourceClusterID.ValueString(), err))
+			return
+		}
+		provisionReq.Source = &client.ClusterSource{
+			SourceClusterID: sourceID,
+			BackupID:        source.BackupID.ValueString(),
+			PointInTime:     source.PointInTime.ValueString(),
+		}
+	}
+
+	if !plan.ReplicaOf.IsNull() && !plan.ReplicaOf.IsUnknown() {
+		primaryID, err := strconv.Atoi(plan.ReplicaOf.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("replica_of"), "Invalid primary cluster ID",
+				fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ReplicaOf.ValueString(), err))
+			return
+		}
+		provisionReq.ReplicaOf = &primaryID
+	}
+
+	window, diags := maintenanceWindowFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	provisionReq.MaintenanceWindow = window
+
+	if !plan.RequireSSL.IsNull() && !plan.RequireSSL.IsUnknown() {
+		requireSSL := plan.RequireSSL.ValueBool()
+		provisionReq.RequireSSL = &requireSSL
+	}
+
+	if !plan.StorageGB.IsNull() && !plan.StorageGB.IsUnknown() {
+		provisionReq.StorageGB = int(plan.StorageGB.ValueInt64())
+	}
+	autoscaling, diags := storageAutoscalingFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if autoscaling.Enabled {
+		provisionReq.StorageAutoscaling = autoscaling
+	}
+
 	tflog.Info(ctx, "Creating cluster", map[string]interface{}{
 		"name":   provisionReq.Name,
 		"region": provisionReq.Region,
@@ -267,15 +760,64 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 		"cluster_id": provisionResp.ID,
 	})
 
+	// Save the ID right away so a timeout or cancellation below leaves the
+	// cluster in state instead of an orphaned, billed cluster.
+	setProvisioningState(provisionResp, &plan)
+	if !plan.Bootstrap.IsNull() {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, bootstrapPendingKey, []byte("true"))...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	cluster, err := r.client.WaitForClusterActive(ctx, provisionResp.ID, 25*time.Minute)
 	if err != nil {
-		resp.Diagnostics.AddError("Error waiting for cluster",
-			fmt.Sprintf("Cluster %d failed to become active: %s", provisionResp.ID, err))
+		if client.IsClusterFailed(err) {
+			resp.Diagnostics.AddError("Error waiting for cluster",
+				fmt.Sprintf("Cluster %d failed to become active: %s", provisionResp.ID, err))
+			return
+		}
+		// Still provisioning: a warning keeps the resource untainted so the
+		// next apply resumes waiting in Update instead of replacing it.
+		resp.Diagnostics.AddWarning("Cluster is still provisioning",
+			fmt.Sprintf("Stopped waiting for cluster %d to become active: %s\n\n"+
+				"The cluster has been saved to state. The next apply resumes waiting for it and applies the remaining configuration.",
+				provisionResp.ID, err))
 		return
 	}
 
+	plan.BootstrapPasswords = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	bootstrapped := plan.Bootstrap.IsNull()
+	if !bootstrapped {
+		passwords, diags := r.bootstrap(ctx, provisionResp.ID, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !passwords.IsNull() {
+			plan.BootstrapPasswords = passwords
+			bootstrapped = true
+		}
+	}
+
+	if plan.State.ValueString() == "paused" {
+		cluster, err = r.pauseCluster(ctx, provisionResp.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error pausing cluster",
+				fmt.Sprintf("Could not pause cluster %d: %s", provisionResp.ID, err))
+			return
+		}
+	}
+
 	mapClusterToState(cluster, &plan)
+	if plan.EstimatedMonthlyCost.IsUnknown() {
+		plan.EstimatedMonthlyCost = r.estimateMonthlyCost(ctx, plan.ServerType, plan.NodeCount)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if bootstrapped {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, bootstrapPendingKey, nil)...)
+	}
 }
 
 func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -304,10 +846,14 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// Preserve extensions from state since they are only used at creation.
-	extensions := state.Extensions
+	prior := state
 	mapClusterToState(cluster, &state)
-	state.Extensions = extensions
+	state.Extensions = installedExtensions(cluster, state.Extensions)
+	state.EstimatedMonthlyCost = r.estimateMonthlyCostOrPrior(ctx, state, &prior)
+
+	if summary, detail, ok := clusterHealthWarning(cluster); ok {
+		resp.Diagnostics.AddWarning(summary, detail)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -327,7 +873,225 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Only node_count can change in-place.
+	// A previous apply may have stopped waiting while the cluster was still
+	// provisioning; finish that before making any other changes.
+	if isProvisioningStatus(state.Status.ValueString()) {
+		tflog.Info(ctx, "Resuming wait for cluster to become active", map[string]interface{}{
+			"cluster_id": id,
+		})
+		if _, err := r.client.WaitForClusterActive(ctx, id, 25*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for cluster",
+				fmt.Sprintf("Cluster %d failed to become active: %s", id, err))
+			return
+		}
+		// Create stored the desired state; the cluster is running now and
+		// is paused below if that is what the plan asks for.
+		state.State = types.StringValue("running")
+	}
+
+	pending, diags := bootstrapPending(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resume a paused cluster before any change that needs running nodes.
+	if state.State.ValueString() == "paused" && plan.State.ValueString() == "running" {
+		tflog.Info(ctx, "Resuming cluster", map[string]interface{}{
+			"cluster_id": id,
+		})
+
+		if _, err := r.client.ResumeCluster(ctx, id); err != nil {
+			resp.Diagnostics.AddError("Error resuming cluster",
+				fmt.Sprintf("Could not resume cluster %d: %s", id, err))
+			return
+		}
+		if _, err := r.client.WaitForClusterResumed(ctx, id, 15*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for cluster to resume",
+				fmt.Sprintf("Cluster %d failed to become active: %s", id, err))
+			return
+		}
+	}
+
+	// Apply a bootstrap block that Create could not, either because it
+	// stopped waiting for the cluster or because the configure job failed.
+	bootstrapped := false
+	if pending && plan.Bootstrap.IsNull() {
+		bootstrapped = true
+	} else if pending && (state.State.ValueString() == "running" || plan.State.ValueString() == "running") {
+		passwords, diags := r.bootstrap(ctx, id, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !passwords.IsNull() {
+			plan.BootstrapPasswords = passwords
+			bootstrapped = true
+		}
+	}
+
+	// Removing replica_of promotes a read replica to a standalone primary.
+	if !state.ReplicaOf.IsNull() && plan.ReplicaOf.IsNull() {
+		tflog.Info(ctx, "Promoting read replica", map[string]interface{}{
+			"cluster_id": id,
+			"replica_of": state.ReplicaOf.ValueString(),
+		})
+
+		if _, err := r.client.PromoteCluster(ctx, id); err != nil {
+			resp.Diagnostics.AddError("Error promoting read replica",
+				fmt.Sprintf("Could not promote cluster %d: %s", id, err))
+			return
+		}
+		if err := r.client.WaitForJobComplete(ctx, id, 15*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for promote job",
+				fmt.Sprintf("Promote job failed for cluster %d: %s", id, err))
+			return
+		}
+	}
+
+	if !plan.PasswordRotationTrigger.IsNull() && !plan.PasswordRotationTrigger.Equal(state.PasswordRotationTrigger) {
+		tflog.Info(ctx, "Rotating default database user password", map[string]interface{}{
+			"cluster_id": id,
+		})
+
+		rotateResp, err := r.client.RotatePassword(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError("Error rotating password",
+				fmt.Sprintf("Could not rotate the password of cluster %d: %s", id, err))
+			return
+		}
+		if rotateResp.JobID > 0 {
+			if err := r.client.WaitForJobComplete(ctx, id, 5*time.Minute); err != nil {
+				resp.Diagnostics.AddError("Error waiting for password rotation",
+					fmt.Sprintf("Password rotation job failed for cluster %d: %s", id, err))
+				return
+			}
+		}
+	}
+
+	// Converting core_solo to ha enables replication first; the replicas are
+	// then added by the node scaling below.
+	if state.DBType.ValueString() == "core_solo" && plan.DBType.ValueString() == "ha" {
+		tflog.Info(ctx, "Converting cluster to HA", map[string]interface{}{
+			"cluster_id": id,
+		})
+
+		convertResp, err := r.client.ConvertToHA(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError("Error converting cluster to HA",
+				fmt.Sprintf("Could not convert cluster %d to HA: %s", id, err))
+			return
+		}
+		if convertResp.JobID > 0 {
+			if err := r.client.WaitForJobComplete(ctx, id, 15*time.Minute); err != nil {
+				resp.Diagnostics.AddError("Error waiting for HA conversion",
+					fmt.Sprintf("HA conversion job failed for cluster %d: %s", id, err))
+				return
+			}
+		}
+	}
+
+	// Metadata such as the name and maintenance window is updated through
+	// PATCH without touching nodes.
+	var updateReq client.UpdateClusterRequest
+	metadataChanged := false
+	if !plan.Name.Equal(state.Name) {
+		updateReq.Name = plan.Name.ValueString()
+		metadataChanged = true
+	}
+	if !plan.MaintenanceWindow.Equal(state.MaintenanceWindow) {
+		window, diags := maintenanceWindowFromPlan(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if window != nil {
+			updateReq.MaintenanceWindow = window
+			metadataChanged = true
+		}
+	}
+	if !plan.RequireSSL.IsUnknown() && !plan.RequireSSL.Equal(state.RequireSSL) {
+		requireSSL := plan.RequireSSL.ValueBool()
+		updateReq.RequireSSL = &requireSSL
+		metadataChanged = true
+	}
+	if !plan.StorageAutoscaling.Equal(state.StorageAutoscaling) {
+		autoscaling, diags := storageAutoscalingFromPlan(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		updateReq.StorageAutoscaling = autoscaling
+		metadataChanged = true
+	}
+
+	if metadataChanged {
+		tflog.Info(ctx, "Updating cluster metadata", map[string]interface{}{
+			"cluster_id": id,
+			"name":       plan.Name.ValueString(),
+		})
+
+		_, err := r.client.UpdateCluster(ctx, id, updateReq)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating cluster",
+				fmt.Sprintf("Could not update cluster %d: %s", id, err))
+			return
+		}
+	}
+
+	if !plan.StorageGB.IsUnknown() && !state.StorageGB.IsNull() && plan.StorageGB.ValueInt64() > state.StorageGB.ValueInt64() {
+		tflog.Info(ctx, "Growing cluster storage", map[string]interface{}{
+			"cluster_id": id,
+			"from":       state.StorageGB.ValueInt64(),
+			"to":         plan.StorageGB.ValueInt64(),
+		})
+
+		resizeResp, err := r.client.ResizeStorage(ctx, id, client.ResizeStorageRequest{
+			StorageGB: int(plan.StorageGB.ValueInt64()),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error resizing storage",
+				fmt.Sprintf("Could not resize storage of cluster %d: %s", id, err))
+			return
+		}
+		if resizeResp.JobID > 0 {
+			if err := r.client.WaitForJobComplete(ctx, id, 30*time.Minute); err != nil {
+				resp.Diagnostics.AddError("Error waiting for storage resize",
+					fmt.Sprintf("Storage resize job failed for cluster %d: %s", id, err))
+				return
+			}
+		}
+	}
+
+	addedExtensions := listDifference(plan.Extensions, state.Extensions)
+	if len(addedExtensions) > 0 {
+		tflog.Info(ctx, "Installing cluster extensions", map[string]interface{}{
+			"cluster_id": id,
+			"extensions": addedExtensions,
+		})
+
+		extReqs := make([]client.ConfigExtensionRequest, 0, len(addedExtensions))
+		for _, ext := range addedExtensions {
+			extReqs = append(extReqs, client.ConfigExtensionRequest{Extension: ext})
+		}
+
+		configResp, err := r.client.ConfigureWithRetry(ctx, id, client.ConfigureRequest{
+			Extensions: extReqs,
+		}, 2*time.Minute)
+		if err != nil {
+			resp.Diagnostics.AddError("Error installing extensions",
+				fmt.Sprintf("Could not install extensions on cluster %d: %s", id, err))
+			return
+		}
+		if configResp.JobID > 0 {
+			if err := r.client.WaitForJobComplete(ctx, id, 5*time.Minute); err != nil {
+				resp.Diagnostics.AddError("Error waiting for extension installation",
+					fmt.Sprintf("Configure job failed for cluster %d: %s", id, err))
+				return
+			}
+		}
+	}
+
 	oldCount := state.NodeCount.ValueInt64()
 	newCount := plan.NodeCount.ValueInt64()
 
@@ -360,10 +1124,23 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 					fmt.Sprintf("Could not read cluster %d for node removal: %s", id, err))
 				return
 			}
-			// Remove nodes from highest number down.
-			for i := oldCount; i > newCount; i-- {
-				nodeName := fmt.Sprintf("%s-db-%d", cluster.TenantID, i)
-				_, err := r.client.RemoveNode(ctx, id, nodeName)
+			policy, diags := scaleDownPolicyFromPlan(ctx, plan)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			victims, err := selectNodesForRemoval(cluster.Nodes, int(oldCount-newCount))
+			if err != nil {
+				resp.Diagnostics.AddError("Error selecting nodes for removal",
+					fmt.Sprintf("Could not scale down cluster %d: %s", id, err))
+				return
+			}
+			for _, nodeName := range victims {
+				_, err := r.client.RemoveNode(ctx, id, client.RemoveNodeRequest{
+					NodeName:           nodeName,
+					DeleteServer:       policy.DeleteServer.ValueBool(),
+					RemovePostgresData: policy.RemovePostgresData.ValueBool(),
+				})
 				if err != nil {
 					resp.Diagnostics.AddError("Error removing node",
 						fmt.Sprintf("Could not remove node %s from cluster %d: %s", nodeName, id, err))
@@ -378,6 +1155,14 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
+	if state.State.ValueString() == "running" && plan.State.ValueString() == "paused" {
+		if _, err := r.pauseCluster(ctx, id); err != nil {
+			resp.Diagnostics.AddError("Error pausing cluster",
+				fmt.Sprintf("Could not pause cluster %d: %s", id, err))
+			return
+		}
+	}
+
 	// Refresh state from API.
 	cluster, err := r.client.GetCluster(ctx, id)
 	if err != nil {
@@ -386,13 +1171,337 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	if plan.BootstrapPasswords.IsUnknown() {
+		plan.BootstrapPasswords = state.BootstrapPasswords
+		if plan.BootstrapPasswords.IsNull() {
+			plan.BootstrapPasswords = types.MapValueMust(types.StringType, map[string]attr.Value{})
+		}
+	}
+
 	extensions := plan.Extensions
 	subscriptionID := plan.SubscriptionID
 	mapClusterToState(cluster, &plan)
+	if plan.EstimatedMonthlyCost.IsUnknown() {
+		plan.EstimatedMonthlyCost = r.estimateMonthlyCostOrPrior(ctx, plan, &state)
+	}
 	plan.Extensions = extensions
 	plan.SubscriptionID = subscriptionID
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if bootstrapped {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, bootstrapPendingKey, nil)...)
+	}
+}
+
+func (r *clusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var dbType types.String
+	var nodeCount types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("db_type"), &dbType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("node_count"), &nodeCount)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if dbType.ValueString() == "core_solo" && !nodeCount.IsNull() && !nodeCount.IsUnknown() && nodeCount.ValueInt64() != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("node_count"), "Invalid node_count for core_solo",
+			fmt.Sprintf("A core_solo cluster runs a single node, but node_count is %d. Use db_type = \"ha\" for multiple nodes.",
+				nodeCount.ValueInt64()))
+	}
+}
+
+func (r *clusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan clusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *clusterResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &clusterResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.validateCatalog(ctx, plan, state, resp)
+
+	// node_count defaults to 2, which only makes sense for ha clusters.
+	if plan.DBType.ValueString() == "core_solo" {
+		var configNodeCount types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("node_count"), &configNodeCount)...)
+		if configNodeCount.IsNull() {
+			plan.NodeCount = types.Int64Value(1)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("node_count"), plan.NodeCount)...)
+		}
+	}
+
+	// Recompute the cost estimate so scaling changes show their cost in the plan.
+	estimate := types.Float64Unknown()
+	if !plan.ServerType.IsUnknown() && !plan.NodeCount.IsUnknown() {
+		estimate = r.estimateMonthlyCostOrPrior(ctx, plan, state)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("estimated_monthly_cost"), estimate)...)
+
+	if !plan.StorageAutoscaling.IsNull() && !plan.StorageAutoscaling.IsUnknown() &&
+		!plan.StorageGB.IsNull() && !plan.StorageGB.IsUnknown() {
+		var autoscaling storageAutoscalingModel
+		resp.Diagnostics.Append(plan.StorageAutoscaling.As(ctx, &autoscaling, basetypes.ObjectAsOptions{})...)
+		if !autoscaling.MaxGB.IsNull() && !autoscaling.MaxGB.IsUnknown() && autoscaling.MaxGB.ValueInt64() < plan.StorageGB.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(path.Root("storage_autoscaling").AtName("max_gb"), "Invalid storage autoscaling limit",
+				fmt.Sprintf("max_gb (%d) must not be lower than storage_gb (%d).", autoscaling.MaxGB.ValueInt64(), plan.StorageGB.ValueInt64()))
+		}
+	}
+
+	if state == nil {
+		return
+	}
+
+	if state.DBType.ValueString() == "core_solo" && plan.DBType.ValueString() == "ha" &&
+		!plan.NodeCount.IsUnknown() && plan.NodeCount.ValueInt64() < 2 {
+		resp.Diagnostics.AddAttributeError(path.Root("node_count"), "Invalid node_count for HA conversion",
+			"Converting a core_solo cluster to ha requires node_count of at least 2 so replicas can be added.")
+	}
+
+	// Force an update while the cluster is still provisioning so the next
+	// apply resumes waiting for it.
+	if isProvisioningStatus(state.Status.ValueString()) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+		// The stored state is only the desired one; the cluster is not
+		// paused until Update has finished waiting for it.
+		state.State = types.StringValue("running")
+	}
+
+	if state.Status.ValueString() == "failed" && plan.ReplaceIfFailed.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("status"))
+	}
+
+	if !plan.StorageGB.IsUnknown() && !plan.StorageGB.IsNull() && plan.StorageGB.ValueInt64() < state.StorageGB.ValueInt64() {
+		if !plan.StorageAutoscaling.IsNull() {
+			// Autoscaling grew the storage past the configured size; keep it.
+			plan.StorageGB = state.StorageGB
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("storage_gb"), state.StorageGB)...)
+		} else {
+			resp.Diagnostics.AddAttributeError(path.Root("storage_gb"), "Storage cannot be reduced",
+				fmt.Sprintf("storage_gb can only be increased, but the plan reduces it from %d to %d GB.",
+					state.StorageGB.ValueInt64(), plan.StorageGB.ValueInt64()))
+		}
+	}
+
+	pending, diags := bootstrapPending(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	switch {
+	case pending && !plan.Bootstrap.IsNull() && state.State.ValueString() == "paused" && plan.State.ValueString() == "paused":
+		resp.Diagnostics.AddAttributeWarning(path.Root("bootstrap"), "Bootstrap is pending",
+			"The bootstrap block has not been applied yet. It is applied once the cluster is running.")
+	case pending:
+		// Force an update so the pending bootstrap is applied.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("bootstrap_passwords"), types.MapUnknown(types.StringType))...)
+	case !plan.Bootstrap.Equal(state.Bootstrap):
+		resp.Diagnostics.AddAttributeWarning(path.Root("bootstrap"), "Bootstrap changes are not applied",
+			"The bootstrap block is only applied when the cluster is created. The change is recorded in state, "+
+				"but users, databases and grants on the existing cluster are left untouched.")
+	}
+
+	if state.State.ValueString() == "paused" && plan.State.ValueString() == "paused" {
+		if changed := runningOnlyChanges(plan, *state); len(changed) > 0 {
+			resp.Diagnostics.AddError("Cluster is paused",
+				fmt.Sprintf("Changing %s requires running nodes. Set state = \"running\" to resume the cluster; "+
+					"it can be paused again in a later apply.", strings.Join(changed, ", ")))
+		}
+	}
+
+	if removed := listDifference(state.Extensions, plan.Extensions); len(removed) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("extensions"),
+			"Extensions will not be uninstalled",
+			fmt.Sprintf("Removing %v from extensions only stops Terraform from tracking them. "+
+				"Extensions cannot be removed from a running cluster and stay installed.", removed))
+	}
+
+	if !plan.NodeCount.IsUnknown() && plan.NodeCount.ValueInt64() < state.NodeCount.ValueInt64() {
+		policy, diags := scaleDownPolicyFromPlan(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if policy.DeleteServer.ValueBool() || policy.RemovePostgresData.ValueBool() {
+			resp.Diagnostics.AddAttributeWarning(path.Root("node_count"),
+				"Scaling down will destroy node data",
+				fmt.Sprintf("Reducing node_count from %d to %d removes %d replica node(s). With the current scale_down_policy "+
+					"(delete_server = %t, remove_postgres_data = %t) the PostgreSQL data on the removed node(s) will be destroyed.",
+					state.NodeCount.ValueInt64(), plan.NodeCount.ValueInt64(),
+					state.NodeCount.ValueInt64()-plan.NodeCount.ValueInt64(),
+					policy.DeleteServer.ValueBool(), policy.RemovePostgresData.ValueBool()))
+		}
+	}
+}
+
+// validateCatalog checks region and server_type against the live catalog when
+// they are set on create or changed. If the catalog cannot be fetched, the
+// built-in lists are used instead.
+func (r *clusterResource) validateCatalog(ctx context.Context, plan clusterResourceModel, state *clusterResourceModel, resp *resource.ModifyPlanResponse) {
+	changed := func(planned, prior types.String) bool {
+		return !planned.IsUnknown() && !planned.IsNull() && (state == nil || !planned.Equal(prior))
+	}
+
+	var priorRegion, priorServerType types.String
+	if state != nil {
+		priorRegion, priorServerType = state.Region, state.ServerType
+	}
+
+	if changed(plan.Region, priorRegion) {
+		valid := r.availableRegions(ctx)
+		if !containsString(valid, plan.Region.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("region"), "Invalid region",
+				fmt.Sprintf("Region %q is not available. Valid regions are: %s.",
+					plan.Region.ValueString(), strings.Join(valid, ", ")))
+		}
+	}
+
+	if changed(plan.ServerType, priorServerType) {
+		valid := r.availableServerTypes(ctx)
+		if !containsString(valid, plan.ServerType.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("server_type"), "Invalid server type",
+				fmt.Sprintf("Server type %q is not available. Valid server types are: %s.",
+					plan.ServerType.ValueString(), strings.Join(valid, ", ")))
+		}
+	}
+}
+
+// estimateMonthlyCost multiplies the server type's price per node by the node
+// count. It returns null when the server type catalog is unavailable.
+func (r *clusterResource) estimateMonthlyCost(ctx context.Context, serverType types.String, nodeCount types.Int64) types.Float64 {
+	if r.client == nil {
+		return types.Float64Null()
+	}
+	resp, err := r.client.CachedServerTypes(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not fetch server type catalog for cost estimate", map[string]interface{}{"error": err.Error()})
+		return types.Float64Null()
+	}
+	for _, st := range resp.ServerTypes {
+		if st.Type == serverType.ValueString() {
+			return types.Float64Value(st.PricePerNode * float64(nodeCount.ValueInt64()))
+		}
+	}
+	return types.Float64Null()
+}
+
+// estimateMonthlyCostOrPrior estimates the cost of m, falling back to the
+// prior estimate when the catalog is unavailable and neither server_type nor
+// node_count changed, so an unreachable catalog does not show up as a diff.
+func (r *clusterResource) estimateMonthlyCostOrPrior(ctx context.Context, m clusterResourceModel, prior *clusterResourceModel) types.Float64 {
+	estimate := r.estimateMonthlyCost(ctx, m.ServerType, m.NodeCount)
+	if estimate.IsNull() && prior != nil && !prior.EstimatedMonthlyCost.IsUnknown() &&
+		m.ServerType.Equal(prior.ServerType) && m.NodeCount.Equal(prior.NodeCount) {
+		return prior.EstimatedMonthlyCost
+	}
+	return estimate
+}
+
+func (r *clusterResource) availableRegions(ctx context.Context) []string {
+	if r.client == nil {
+		return fallbackRegions
+	}
+	resp, err := r.client.CachedRegions(ctx)
+	if err != nil || len(resp.Regions) == 0 {
+		tflog.Warn(ctx, "Could not fetch region catalog, using built-in list", map[string]interface{}{"error": fmt.Sprint(err)})
+		return fallbackRegions
+	}
+	names := make([]string, 0, len(resp.Regions))
+	for _, region := range resp.Regions {
+		names = append(names, region.Name)
+	}
+	return names
+}
+
+func (r *clusterResource) availableServerTypes(ctx context.Context) []string {
+	if r.client == nil {
+		return fallbackServerTypes
+	}
+	resp, err := r.client.CachedServerTypes(ctx)
+	if err != nil || len(resp.ServerTypes) == 0 {
+		tflog.Warn(ctx, "Could not fetch server type catalog, using built-in list", map[string]interface{}{"error": fmt.Sprint(err)})
+		return fallbackServerTypes
+	}
+	names := make([]string, 0, len(resp.ServerTypes))
+	for _, st := range resp.ServerTypes {
+		names = append(names, st.Type)
+	}
+	return names
+}
+
+// bootstrap applies the bootstrap block of plan. A failed configure job is
+// reported as a warning so a healthy cluster is not tainted; the returned map
+// is then null and the bootstrap stays pending for the next apply.
+func (r *clusterResource) bootstrap(ctx context.Context, id int, plan clusterResourceModel) (types.Map, diag.Diagnostics) {
+	configReq, diags := bootstrapRequest(ctx, plan)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+	passwords, err := r.applyBootstrap(ctx, id, configReq)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("bootstrap"), "Cluster bootstrap failed",
+			fmt.Sprintf("Cluster %d is active but the bootstrap configuration failed: %s\n\n"+
+				"The next apply retries the bootstrap.", id, err))
+	}
+	return passwords, diags
+}
+
+// bootstrapPending reports whether the bootstrap block still has to be
+// applied, as recorded in the resource's private state.
+func bootstrapPending(ctx context.Context, private interface {
+	GetKey(context.Context, string) ([]byte, diag.Diagnostics)
+}) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, bootstrapPendingKey)
+	return len(value) > 0, diags
+}
+
+// applyBootstrap sends the bootstrap block as a single configure request and
+// returns the generated passwords keyed by username.
+func (r *clusterResource) applyBootstrap(ctx context.Context, id int, configReq client.ConfigureRequest) (types.Map, error) {
+	tflog.Info(ctx, "Bootstrapping cluster", map[string]interface{}{
+		"cluster_id": id,
+		"users":      len(configReq.Users),
+		"databases":  len(configReq.Databases),
+		"grants":     len(configReq.Grants),
+	})
+
+	configResp, err := r.client.ConfigureWithRetry(ctx, id, configReq, 2*time.Minute)
+	if err != nil {
+		return types.MapNull(types.StringType), err
+	}
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, id, 10*time.Minute); err != nil {
+			return types.MapNull(types.StringType), fmt.Errorf("configure job failed: %w", err)
+		}
+	}
+
+	passwords := make(map[string]attr.Value, len(configResp.Users))
+	for _, u := range configResp.Users {
+		passwords[u.Username] = types.StringValue(u.Password)
+	}
+	return types.MapValueMust(types.StringType, passwords), nil
+}
+
+// pauseCluster pauses the cluster and waits until its nodes are stopped.
+func (r *clusterResource) pauseCluster(ctx context.Context, id int) (*client.Cluster, error) {
+	tflog.Info(ctx, "Pausing cluster", map[string]interface{}{
+		"cluster_id": id,
+	})
+
+	if _, err := r.client.PauseCluster(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.client.WaitForClusterPaused(ctx, id, 15*time.Minute)
 }
 
 func (r *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -444,10 +1553,373 @@ func mapClusterToState(c *client.Cluster, state *clusterResourceModel) {
 	state.PostgreSQLVersion = types.Int64Value(int64(c.PostgreSQLVersion))
 	state.TenantID = types.StringValue(c.TenantID)
 	state.Status = types.StringValue(c.Status)
+	state.HealthStatus = types.StringValue(c.HealthStatus)
+	switch c.Status {
+	case "paused", "pausing":
+		state.State = types.StringValue("paused")
+	case "active", "resuming":
+		state.State = types.StringValue("running")
+	default:
+		// Keep the desired state while the cluster is provisioning or failed.
+		if state.State.IsNull() || state.State.IsUnknown() {
+			state.State = types.StringValue("running")
+		}
+	}
 	state.Host = types.StringValue(c.Host)
-	state.ConnectionString = types.StringValue(c.ConnectionString)
+	state.ConnectionString = types.StringValue(client.WithSSLMode(c.ConnectionString, c.RequireSSL))
+	state.PooledConnectionString = types.StringValue(client.WithSSLMode(c.PooledConnectionString, c.RequireSSL))
+	state.ReadOnlyHost = types.StringValue(c.ReadOnlyHost)
+	state.ReadOnlyConnectionString = types.StringValue(client.WithSSLMode(c.ReadOnlyConnectionString, c.RequireSSL))
+	state.RequireSSL = types.BoolValue(c.RequireSSL)
+	state.CACertificate = types.StringValue(c.CACertificate)
 	state.DBUser = types.StringValue(c.DBUser)
 	state.DBPassword = types.StringValue(c.DBPassword)
 	state.CreatedAt = types.StringValue(c.CreatedAt.Format(time.RFC3339))
 	state.UpdatedAt = types.StringValue(c.UpdatedAt.Format(time.RFC3339))
+	state.Nodes = nodesToList(c.Nodes)
+	state.StorageGB = types.Int64Value(int64(c.StorageGB))
+	state.StorageUsedGB = types.Float64Value(c.StorageUsedGB)
+	state.StorageAutoscaling = types.ObjectNull(storageAutoscalingAttrTypes)
+	if c.StorageAutoscaling != nil && c.StorageAutoscaling.Enabled {
+		state.StorageAutoscaling = types.ObjectValueMust(storageAutoscalingAttrTypes, map[string]attr.Value{
+			"threshold_percent": types.Int64Value(int64(c.StorageAutoscaling.ThresholdPercent)),
+			"max_gb":            types.Int64Value(int64(c.StorageAutoscaling.MaxGB)),
+		})
+	}
+	state.ReplicaOf = types.StringNull()
+	state.ReplicationLag = types.Int64Null()
+	if c.ReplicaOf != nil {
+		state.ReplicaOf = types.StringValue(strconv.Itoa(*c.ReplicaOf))
+		state.ReplicationLag = types.Int64Value(c.ReplicationLag)
+	}
+	state.MaintenanceWindow = types.ObjectNull(maintenanceWindowAttrTypes)
+	if c.MaintenanceWindow != nil {
+		state.MaintenanceWindow = types.ObjectValueMust(maintenanceWindowAttrTypes, map[string]attr.Value{
+			"day_of_week":    types.StringValue(c.MaintenanceWindow.DayOfWeek),
+			"start_hour":     types.Int64Value(int64(c.MaintenanceWindow.StartHour)),
+			"duration_hours": types.Int64Value(int64(c.MaintenanceWindow.DurationHours)),
+		})
+	}
+	state.NextMaintenanceAt = types.StringNull()
+	if c.NextMaintenanceAt != nil {
+		state.NextMaintenanceAt = types.StringValue(c.NextMaintenanceAt.Format(time.RFC3339))
+	}
+	pending := make([]attr.Value, 0, len(c.PendingMaintenance))
+	for _, action := range c.PendingMaintenance {
+		pending = append(pending, types.StringValue(action))
+	}
+	state.PendingMaintenanceActions = types.ListValueMust(types.StringType, pending)
+	state.RestoredFrom = types.ObjectNull(sourceAttrTypes)
+	if c.RestoredFrom != nil {
+		state.RestoredFrom = types.ObjectValueMust(sourceAttrTypes, map[string]attr.Value{
+			"source_cluster_id": types.StringValue(strconv.Itoa(c.RestoredFrom.SourceClusterID)),
+			"backup_id":         types.StringValue(c.RestoredFrom.BackupID),
+			"point_in_time":     types.StringValue(c.RestoredFrom.PointInTime),
+		})
+	}
+}
+
+// clusterHealthWarning describes a cluster that is not active or not healthy.
+func clusterHealthWarning(c *client.Cluster) (string, string, bool) {
+	var summary string
+	switch {
+	case c.Status == "failed":
+		summary = "Cluster has failed"
+	case c.Status != "active" && !isProvisioningStatus(c.Status) && !isPausedStatus(c.Status):
+		summary = "Cluster is not active"
+	case c.HealthStatus != "" && c.HealthStatus != "healthy" && !isPausedStatus(c.Status):
+		summary = "Cluster is not healthy"
+	default:
+		return "", "", false
+	}
+
+	detail := fmt.Sprintf("Cluster %d has status %q and health status %q.", c.ID, c.Status, c.HealthStatus)
+	if c.ErrorMessage != "" {
+		detail += fmt.Sprintf(" Error: %s", c.ErrorMessage)
+	}
+	if c.Status == "failed" {
+		detail += " Set replace_if_failed = true to plan a replacement."
+	}
+	return summary, detail, true
+}
+
+func isProvisioningStatus(status string) bool {
+	return status == "provisioning" || status == "restoring"
+}
+
+// isPausedStatus reports whether the cluster's nodes are intentionally
+// stopped or about to change power state.
+func isPausedStatus(status string) bool {
+	return status == "paused" || status == "pausing" || status == "resuming"
+}
+
+// runningOnlyChanges lists the planned changes that cannot be applied while
+// the cluster is paused.
+func runningOnlyChanges(plan, state clusterResourceModel) []string {
+	var changed []string
+	if !plan.NodeCount.IsUnknown() && !plan.NodeCount.Equal(state.NodeCount) {
+		changed = append(changed, "node_count")
+	}
+	if !plan.DBType.Equal(state.DBType) {
+		changed = append(changed, "db_type")
+	}
+	if !plan.StorageGB.IsUnknown() && !plan.StorageGB.Equal(state.StorageGB) {
+		changed = append(changed, "storage_gb")
+	}
+	if len(listDifference(plan.Extensions, state.Extensions)) > 0 {
+		changed = append(changed, "extensions")
+	}
+	if !state.ReplicaOf.IsNull() && plan.ReplicaOf.IsNull() {
+		changed = append(changed, "replica_of")
+	}
+	if !plan.PasswordRotationTrigger.IsNull() && !plan.PasswordRotationTrigger.Equal(state.PasswordRotationTrigger) {
+		changed = append(changed, "password_rotation_trigger")
+	}
+	return changed
+}
+
+// setProvisioningState fills the state from a provision response before the
+// cluster is active. Attributes that are not known yet are set to null.
+func setProvisioningState(p *client.ProvisionClusterResponse, state *clusterResourceModel) {
+	state.ID = types.StringValue(strconv.Itoa(p.ID))
+	state.TenantID = types.StringValue(p.TenantID)
+	state.Status = types.StringValue(p.Status)
+	state.HealthStatus = types.StringNull()
+	state.CreatedAt = types.StringValue(p.CreatedAt.Format(time.RFC3339))
+	state.UpdatedAt = types.StringNull()
+	state.Host = types.StringNull()
+	state.ConnectionString = types.StringNull()
+	state.PooledConnectionString = types.StringNull()
+	state.ReadOnlyHost = types.StringNull()
+	state.ReadOnlyConnectionString = types.StringNull()
+	state.CACertificate = types.StringNull()
+	if state.RequireSSL.IsUnknown() {
+		state.RequireSSL = types.BoolNull()
+	}
+	state.DBUser = types.StringNull()
+	state.DBPassword = types.StringNull()
+	state.Nodes = types.ListNull(types.ObjectType{AttrTypes: nodeAttrTypes})
+	state.RestoredFrom = types.ObjectNull(sourceAttrTypes)
+	state.ReplicationLag = types.Int64Null()
+	state.NextMaintenanceAt = types.StringNull()
+	state.PendingMaintenanceActions = types.ListNull(types.StringType)
+	if state.MaintenanceWindow.IsUnknown() {
+		state.MaintenanceWindow = types.ObjectNull(maintenanceWindowAttrTypes)
+	}
+	if state.StorageGB.IsUnknown() {
+		state.StorageGB = types.Int64Null()
+	}
+	state.StorageUsedGB = types.Float64Null()
+	if state.BootstrapPasswords.IsUnknown() {
+		state.BootstrapPasswords = types.MapNull(types.StringType)
+	}
+	if state.EstimatedMonthlyCost.IsUnknown() {
+		state.EstimatedMonthlyCost = types.Float64Null()
+	}
+}
+
+// bootstrapRequest converts the bootstrap block into a configure request.
+func bootstrapRequest(ctx context.Context, plan clusterResourceModel) (client.ConfigureRequest, diag.Diagnostics) {
+	var req client.ConfigureRequest
+	var bootstrap bootstrapModel
+	diags := plan.Bootstrap.As(ctx, &bootstrap, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return req, diags
+	}
+
+	var users []string
+	diags.Append(bootstrap.Users.ElementsAs(ctx, &users, false)...)
+	for _, u := range users {
+		req.Users = append(req.Users, client.ConfigUserRequest{Username: u})
+	}
+
+	var databases []bootstrapDatabaseModel
+	diags.Append(bootstrap.Databases.ElementsAs(ctx, &databases, false)...)
+	for _, db := range databases {
+		req.Databases = append(req.Databases, client.ConfigDatabaseRequest{
+			Name:  db.Name.ValueString(),
+			Owner: db.Owner.ValueString(),
+		})
+	}
+
+	var grants []bootstrapGrantModel
+	diags.Append(bootstrap.Grants.ElementsAs(ctx, &grants, false)...)
+	for _, g := range grants {
+		req.Grants = append(req.Grants, client.ConfigGrantRequest{
+			Username: g.Username.ValueString(),
+			Database: g.Database.ValueString(),
+			Access:   g.Access.ValueString(),
+		})
+	}
+
+	var extensions []bootstrapExtensionModel
+	diags.Append(bootstrap.Extensions.ElementsAs(ctx, &extensions, false)...)
+	for _, ext := range extensions {
+		req.Extensions = append(req.Extensions, client.ConfigExtensionRequest{
+			Extension: ext.Extension.ValueString(),
+			Database:  ext.Database.ValueString(),
+		})
+	}
+
+	diags.Append(bootstrap.AllowedCIDRs.ElementsAs(ctx, &req.SourceIPs, false)...)
+	return req, diags
+}
+
+// scaleDownPolicyFromPlan returns the configured scale_down_policy, falling
+// back to deleting both server and data when the block is omitted.
+func scaleDownPolicyFromPlan(ctx context.Context, plan clusterResourceModel) (scaleDownPolicyModel, diag.Diagnostics) {
+	policy := scaleDownPolicyModel{
+		DeleteServer:       types.BoolValue(true),
+		RemovePostgresData: types.BoolValue(true),
+	}
+	if plan.ScaleDownPolicy.IsNull() || plan.ScaleDownPolicy.IsUnknown() {
+		return policy, nil
+	}
+	diags := plan.ScaleDownPolicy.As(ctx, &policy, basetypes.ObjectAsOptions{})
+	return policy, diags
+}
+
+// installedExtensions returns the configured extensions that are actually
+// installed on the cluster's default database, so that an extension missing
+// from the cluster shows up as drift. Extensions installed by other means are
+// not reported to avoid fighting rivestack_cluster_extension resources.
+func installedExtensions(c *client.Cluster, configured types.List) types.List {
+	if configured.IsNull() || configured.IsUnknown() {
+		return configured
+	}
+
+	installed := make(map[string]bool, len(c.Extensions))
+	for _, ext := range c.Extensions {
+		if ext.Database == "" || ext.Database == c.DBName {
+			installed[ext.Extension] = true
+		}
+	}
+
+	elems := make([]attr.Value, 0, len(configured.Elements()))
+	for _, v := range configured.Elements() {
+		if s, ok := v.(types.String); ok && installed[s.ValueString()] {
+			elems = append(elems, s)
+		}
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// listDifference returns the string elements of a that are not present in b.
+func listDifference(a, b types.List) []string {
+	if a.IsNull() || a.IsUnknown() {
+		return nil
+	}
+	seen := make(map[string]bool)
+	if !b.IsNull() && !b.IsUnknown() {
+		for _, v := range b.Elements() {
+			if s, ok := v.(types.String); ok {
+				seen[s.ValueString()] = true
+			}
+		}
+	}
+	var diff []string
+	for _, v := range a.Elements() {
+		if s, ok := v.(types.String); ok && !seen[s.ValueString()] {
+			diff = append(diff, s.ValueString())
+		}
+	}
+	return diff
+}
+
+// maintenanceWindowFromPlan returns the configured maintenance window, or nil
+// when it is left to Rivestack.
+func maintenanceWindowFromPlan(ctx context.Context, plan clusterResourceModel) (*client.MaintenanceWindow, diag.Diagnostics) {
+	if plan.MaintenanceWindow.IsNull() || plan.MaintenanceWindow.IsUnknown() {
+		return nil, nil
+	}
+	var window maintenanceWindowModel
+	diags := plan.MaintenanceWindow.As(ctx, &window, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &client.MaintenanceWindow{
+		DayOfWeek:     window.DayOfWeek.ValueString(),
+		StartHour:     int(window.StartHour.ValueInt64()),
+		DurationHours: int(window.DurationHours.ValueInt64()),
+	}, diags
+}
+
+// storageAutoscalingFromPlan returns the configured storage autoscaling. An
+// omitted block disables autoscaling.
+func storageAutoscalingFromPlan(ctx context.Context, plan clusterResourceModel) (*client.StorageAutoscaling, diag.Diagnostics) {
+	if plan.StorageAutoscaling.IsNull() || plan.StorageAutoscaling.IsUnknown() {
+		return &client.StorageAutoscaling{Enabled: false}, nil
+	}
+	var autoscaling storageAutoscalingModel
+	diags := plan.StorageAutoscaling.As(ctx, &autoscaling, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &client.StorageAutoscaling{
+		Enabled:          true,
+		ThresholdPercent: int(autoscaling.ThresholdPercent.ValueInt64()),
+		MaxGB:            int(autoscaling.MaxGB.ValueInt64()),
+	}, diags
+}
+
+func nodesToList(nodes []client.ClusterNode) types.List {
+	elemType := types.ObjectType{AttrTypes: nodeAttrTypes}
+	elems := make([]attr.Value, 0, len(nodes))
+	for _, n := range nodes {
+		elems = append(elems, types.ObjectValueMust(nodeAttrTypes, map[string]attr.Value{
+			"name":   types.StringValue(n.Name),
+			"role":   types.StringValue(n.Role),
+			"status": types.StringValue(n.Status),
+			"lag":    types.Int64Value(n.Lag),
+			"ip":     types.StringValue(n.IP),
+			"host":   types.StringValue(n.Host),
+		}))
+	}
+	return types.ListValueMust(elemType, elems)
+}
+
+// selectNodesForRemoval picks count replica nodes to remove. The leader is
+// never selected. Unhealthy replicas are removed first, then the remaining
+// replicas in reverse name order so the newest nodes go first.
+func selectNodesForRemoval(nodes []client.ClusterNode, count int) ([]string, error) {
+	var replicas []client.ClusterNode
+	for _, n := range nodes {
+		if n.Role != "leader" {
+			replicas = append(replicas, n)
+		}
+	}
+
+	if len(replicas) < count {
+		return nil, fmt.Errorf("need to remove %d node(s) but only %d replica(s) are available", count, len(replicas))
+	}
+
+	sort.SliceStable(replicas, func(i, j int) bool {
+		iHealthy := isHealthyNode(replicas[i])
+		jHealthy := isHealthyNode(replicas[j])
+		if iHealthy != jHealthy {
+			return !iHealthy
+		}
+		return replicas[i].Name > replicas[j].Name
+	})
+
+	names := make([]string, 0, count)
+	for _, n := range replicas[:count] {
+		names = append(names, n.Name)
+	}
+	return names, nil
+}
+
+// isHealthyNode reports whether a node is serving normally. Replicas report
+// streaming while they follow the leader.
+func isHealthyNode(n client.ClusterNode) bool {
+	return n.Status == "running" || n.Status == "streaming"
 }
@@ -0,0 +1,159 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+var (
+	testDatabaseType  = types.ObjectType{AttrTypes: map[string]attr.Type{"name": types.StringType, "owner": types.StringType}}
+	testGrantType     = types.ObjectType{AttrTypes: map[string]attr.Type{"username": types.StringType, "database": types.StringType, "access": types.StringType}}
+	testExtensionType = types.ObjectType{AttrTypes: map[string]attr.Type{"extension": types.StringType, "database": types.StringType}}
+	testBootstrapType = map[string]attr.Type{
+		"users":         types.ListType{ElemType: types.StringType},
+		"databases":     types.ListType{ElemType: testDatabaseType},
+		"grants":        types.ListType{ElemType: testGrantType},
+		"extensions":    types.ListType{ElemType: testExtensionType},
+		"allowed_cidrs": types.ListType{ElemType: types.StringType},
+	}
+)
+
+func TestBootstrapRequest_NullLists(t *testing.T) {
+	plan := clusterResourceModel{
+		Bootstrap: types.ObjectValueMust(testBootstrapType, map[string]attr.Value{
+			"users":         types.ListNull(types.StringType),
+			"databases":     types.ListNull(testDatabaseType),
+			"grants":        types.ListNull(testGrantType),
+			"extensions":    types.ListNull(testExtensionType),
+			"allowed_cidrs": types.ListNull(types.StringType),
+		}),
+	}
+
+	req, diags := bootstrapRequest(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(req.Users) != 0 || len(req.Databases) != 0 || len(req.Grants) != 0 || len(req.Extensions) != 0 || len(req.SourceIPs) != 0 {
+		t.Errorf("expected an empty configure request, got %+v", req)
+	}
+}
+
+func TestBootstrapRequest(t *testing.T) {
+	plan := clusterResourceModel{
+		Bootstrap: types.ObjectValueMust(testBootstrapType, map[string]attr.Value{
+			"users": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("app")}),
+			"databases": types.ListValueMust(testDatabaseType, []attr.Value{
+				types.ObjectValueMust(testDatabaseType.AttrTypes, map[string]attr.Value{
+					"name":  types.StringValue("appdb"),
+					"owner": types.StringValue("app"),
+				}),
+			}),
+			"grants": types.ListValueMust(testGrantType, []attr.Value{
+				types.ObjectValueMust(testGrantType.AttrTypes, map[string]attr.Value{
+					"username": types.StringValue("app"),
+					"database": types.StringValue("appdb"),
+					"access":   types.StringValue("read"),
+				}),
+			}),
+			"extensions": types.ListValueMust(testExtensionType, []attr.Value{
+				types.ObjectValueMust(testExtensionType.AttrTypes, map[string]attr.Value{
+					"extension": types.StringValue("vector"),
+					"database":  types.StringNull(),
+				}),
+			}),
+			"allowed_cidrs": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/8")}),
+		}),
+	}
+
+	req, diags := bootstrapRequest(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(req.Users) != 1 || req.Users[0].Username != "app" {
+		t.Errorf("expected user app, got %+v", req.Users)
+	}
+	if len(req.Databases) != 1 || req.Databases[0] != (client.ConfigDatabaseRequest{Name: "appdb", Owner: "app"}) {
+		t.Errorf("expected database appdb owned by app, got %+v", req.Databases)
+	}
+	if len(req.Grants) != 1 || req.Grants[0].Username != "app" || req.Grants[0].Database != "appdb" || req.Grants[0].Access != "read" {
+		t.Errorf("expected read grant for app on appdb, got %+v", req.Grants)
+	}
+	if len(req.Extensions) != 1 || req.Extensions[0] != (client.ConfigExtensionRequest{Extension: "vector"}) {
+		t.Errorf("expected extension vector on the default database, got %+v", req.Extensions)
+	}
+	if len(req.SourceIPs) != 1 || req.SourceIPs[0] != "10.0.0.0/8" {
+		t.Errorf("expected source IP 10.0.0.0/8, got %q", req.SourceIPs)
+	}
+}
+
+func TestSelectNodesForRemoval(t *testing.T) {
+	nodes := []client.ClusterNode{
+		{Name: "rs-abc123-db-1", Role: "leader", Status: "running"},
+		{Name: "rs-abc123-db-2", Role: "replica", Status: "streaming"},
+		{Name: "rs-abc123-db-3", Role: "replica", Status: "running"},
+		{Name: "rs-abc123-db-4", Role: "replica", Status: "streaming"},
+		{Name: "rs-abc123-db-5", Role: "replica", Status: "stopped"},
+	}
+
+	tests := []struct {
+		count int
+		want  []string
+	}{
+		{count: 1, want: []string{"rs-abc123-db-5"}},
+		{count: 2, want: []string{"rs-abc123-db-5", "rs-abc123-db-4"}},
+		{count: 3, want: []string{"rs-abc123-db-5", "rs-abc123-db-4", "rs-abc123-db-3"}},
+		{count: 4, want: []string{"rs-abc123-db-5", "rs-abc123-db-4", "rs-abc123-db-3", "rs-abc123-db-2"}},
+	}
+	for _, tt := range tests {
+		got, err := selectNodesForRemoval(nodes, tt.count)
+		if err != nil {
+			t.Fatalf("count %d: unexpected error: %v", tt.count, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("count %d: expected %q, got %q", tt.count, tt.want, got)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("count %d: expected %q, got %q", tt.count, tt.want, got)
+				break
+			}
+		}
+	}
+
+	if _, err := selectNodesForRemoval(nodes, 5); err == nil {
+		t.Error("expected an error when removing more nodes than there are replicas")
+	}
+}
+
+func TestSetProvisioningState(t *testing.T) {
+	plan := clusterResourceModel{
+		State:                types.StringValue("paused"),
+		EstimatedMonthlyCost: types.Float64Unknown(),
+		RequireSSL:           types.BoolUnknown(),
+		StorageGB:            types.Int64Unknown(),
+		BootstrapPasswords:   types.MapUnknown(types.StringType),
+	}
+
+	setProvisioningState(&client.ProvisionClusterResponse{ID: 42, TenantID: "rs-abc123", Status: "provisioning"}, &plan)
+
+	if plan.ID.ValueString() != "42" || plan.Status.ValueString() != "provisioning" {
+		t.Errorf("expected cluster 42 provisioning, got %s %s", plan.ID, plan.Status)
+	}
+	if plan.State.ValueString() != "paused" {
+		t.Errorf("expected the planned state paused to be kept, got %s", plan.State)
+	}
+	if !plan.EstimatedMonthlyCost.IsNull() {
+		t.Errorf("expected estimated_monthly_cost to be null, got %s", plan.EstimatedMonthlyCost)
+	}
+	if !plan.RequireSSL.IsNull() || !plan.StorageGB.IsNull() || !plan.BootstrapPasswords.IsNull() {
+		t.Errorf("expected unknown computed values to be null, got %s %s %s", plan.RequireSSL, plan.StorageGB, plan.BootstrapPasswords)
+	}
+}
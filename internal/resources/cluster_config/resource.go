@@ -0,0 +1,868 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+// importedKey is the private state key recording that the resource was just
+// imported and its first Read should take over every kind of object.
+const importedKey = "imported"
+
+var (
+	_ resource.Resource                = &clusterConfigResource{}
+	_ resource.ResourceWithImportState = &clusterConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &clusterConfigResource{}
+)
+
+func NewResource() resource.Resource {
+	return &clusterConfigResource{}
+}
+
+type clusterConfigResource struct {
+	client *client.Client
+}
+
+type clusterConfigResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ClusterID    types.String `tfsdk:"cluster_id"`
+	Users        types.Set    `tfsdk:"users"`
+	Databases    types.Set    `tfsdk:"databases"`
+	Grants       types.Set    `tfsdk:"grants"`
+	Extensions   types.Set    `tfsdk:"extensions"`
+	AllowedCIDRs types.Set    `tfsdk:"allowed_cidrs"`
+	Passwords    types.Map    `tfsdk:"passwords"`
+}
+
+type databaseModel struct {
+	Name  types.String `tfsdk:"name"`
+	Owner types.String `tfsdk:"owner"`
+}
+
+type grantModel struct {
+	Username types.String `tfsdk:"username"`
+	Database types.String `tfsdk:"database"`
+	Access   types.String `tfsdk:"access"`
+}
+
+type extensionModel struct {
+	Extension types.String `tfsdk:"extension"`
+	Database  types.String `tfsdk:"database"`
+}
+
+var databaseAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"owner": types.StringType,
+}
+
+var grantAttrTypes = map[string]attr.Type{
+	"username": types.StringType,
+	"database": types.StringType,
+	"access":   types.StringType,
+}
+
+var extensionAttrTypes = map[string]attr.Type{
+	"extension": types.StringType,
+	"database":  types.StringType,
+}
+
+// accessConfig is the set of access objects of a cluster. A nil slice means
+// the kind of object is not managed.
+type accessConfig struct {
+	users      []string
+	databases  []client.ClusterDatabase
+	grants     []client.ClusterGrant
+	extensions []client.ClusterExtension
+	cidrs      []string
+}
+
+func (r *clusterConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_config"
+}
+
+func (r *clusterConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Authoritatively manages the users, databases, grants, extensions and allowed CIDRs of a Rivestack HA PostgreSQL cluster. " +
+			"Objects of a managed kind that are not in the configuration are removed, including those created outside Terraform. " +
+			"Omit an attribute to leave that kind of object unmanaged. The cluster's default user and database are never removed. " +
+			"Destroying this resource removes the managed users, databases and grants; the IP allowlist and extensions are left in place. " +
+			"Do not combine with the individual rivestack_cluster_* resources for the same kind of object.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (cluster_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"users": schema.SetAttribute{
+				Description: "Usernames that should exist on the cluster. Generated passwords are exported in passwords.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"databases": schema.SetNestedAttribute{
+				Description: "Databases that should exist on the cluster.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Database name.",
+							Required:    true,
+						},
+						"owner": schema.StringAttribute{
+							Description: "Owner of the database. Only used when the database is created; changes to the owner of an existing database are neither applied nor reported as drift.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"grants": schema.SetNestedAttribute{
+				Description: "Database-wide read/write access grants that should exist on the cluster. Schema- and object-level grants and grants with explicit privileges are left untouched.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							Description: "Username to grant access to.",
+							Required:    true,
+						},
+						"database": schema.StringAttribute{
+							Description: "Database to grant access on.",
+							Required:    true,
+						},
+						"access": schema.StringAttribute{
+							Description: "Access level: read or write. Defaults to write.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("write"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("read", "write"),
+							},
+						},
+					},
+				},
+			},
+			"extensions": schema.SetNestedAttribute{
+				Description: "Extensions that should be installed. Extensions installed by other means are ignored. Extensions cannot be uninstalled; removing one only stops tracking it.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"extension": schema.StringAttribute{
+							Description: "Extension name.",
+							Required:    true,
+						},
+						"database": schema.StringAttribute{
+							Description: "Database the extension is installed on.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"allowed_cidrs": schema.SetAttribute{
+				Description: "Complete source IP allowlist of the cluster.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"passwords": schema.MapAttribute{
+				Description: "Generated passwords of the users created by this resource, keyed by username.",
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *clusterConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *clusterConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state clusterConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := configFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	current, diags := configFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Passwords are generated for new users and dropped for removed ones, so
+	// they are only known in advance when the users stay the same.
+	if !plan.Users.Equal(state.Users) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("passwords"), types.MapUnknown(types.StringType))...)
+	}
+
+	if desired.databases != nil {
+		if changed := changedOwners(current.databases, desired.databases); len(changed) > 0 {
+			resp.Diagnostics.AddAttributeWarning(path.Root("databases"), "Database owners will not be changed",
+				fmt.Sprintf("The owner of an existing database cannot be changed. %s will keep the current owner.",
+					strings.Join(changed, ", ")))
+		}
+	}
+	if desired.extensions != nil {
+		if removed := missingExtensions(current.extensions, desired.extensions); len(removed) > 0 {
+			resp.Diagnostics.AddAttributeWarning(path.Root("extensions"), "Extensions will not be uninstalled",
+				fmt.Sprintf("Extensions cannot be removed from a running cluster. %s will stay installed.",
+					strings.Join(removed, ", ")))
+		}
+	}
+}
+
+func (r *clusterConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clusterConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ClusterID.ValueString())
+	plan.Passwords = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	r.apply(ctx, clusterID, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clusterConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	// On import nothing is managed yet; take over every kind of object.
+	marker, diags := req.Private.GetKey(ctx, importedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	imported := len(marker) > 0
+
+	prior, diags := configFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := currentConfig(cluster)
+	if imported || !state.Users.IsNull() {
+		state.Users = stringSet(current.users)
+	}
+	if imported || !state.Databases.IsNull() {
+		state.Databases = databasesToSet(current.databases, priorOwners(ctx, state.Databases))
+	}
+	if imported || !state.Grants.IsNull() {
+		state.Grants = grantsToSet(current.grants)
+	}
+	if imported {
+		state.Extensions = extensionsToSet(current.extensions)
+	} else if !state.Extensions.IsNull() {
+		// Extensions are additive: only track those already in state, so
+		// extensions installed by the cluster resource or the platform do
+		// not show up as drift that can never be resolved.
+		state.Extensions = extensionsToSet(installedExtensions(current.extensions, prior.extensions))
+	}
+	if imported || !state.AllowedCIDRs.IsNull() {
+		state.AllowedCIDRs = stringSet(current.cidrs)
+	}
+	if state.Passwords.IsNull() {
+		state.Passwords = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	if imported {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedKey, nil)...)
+	}
+}
+
+func (r *clusterConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state clusterConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	if plan.Passwords.IsUnknown() {
+		plan.Passwords = state.Passwords
+	}
+	r.apply(ctx, clusterID, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state clusterConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	managed, diags := configFromModel(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the users, databases and grants this resource manages. The
+	// allowlist is left in place so destroying the resource does not lock
+	// every client out, and extensions cannot be removed through the API.
+	configReq := client.ConfigureRequest{
+		DeleteUsers: managed.users,
+	}
+	for _, db := range managed.databases {
+		configReq.DeleteDatabases = append(configReq.DeleteDatabases, db.DBName)
+	}
+	for _, g := range managed.grants {
+		configReq.DeleteGrants = append(configReq.DeleteGrants, client.ConfigGrantRequest{Username: g.Username, Database: g.Database})
+	}
+	if isEmptyConfigureRequest(configReq) {
+		return
+	}
+
+	tflog.Info(ctx, "Removing cluster access configuration", map[string]interface{}{
+		"cluster_id": clusterID,
+		"users":      configReq.DeleteUsers,
+		"databases":  configReq.DeleteDatabases,
+		"grants":     len(configReq.DeleteGrants),
+	})
+
+	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, configReq, 2*time.Minute)
+	if err != nil && client.IsUnsupported(err) && len(configReq.DeleteGrants) > 0 {
+		resp.Diagnostics.AddWarning("Grants not revoked",
+			fmt.Sprintf("The Rivestack API did not accept the revocation of grants on cluster %d: %s. "+
+				"The grants remain on the cluster but are removed from Terraform state.", clusterID, err))
+		configReq.DeleteGrants = nil
+		if isEmptyConfigureRequest(configReq) {
+			return
+		}
+		configResp, err = r.client.ConfigureWithRetry(ctx, clusterID, configReq, 2*time.Minute)
+	}
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting cluster configuration",
+			fmt.Sprintf("Could not remove access configuration from cluster %d: %s", clusterID, err))
+		return
+	}
+
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, clusterID, 10*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for configuration removal",
+				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+			return
+		}
+	}
+	if len(configReq.DeleteGrants) == 0 {
+		return
+	}
+
+	// An API without revocation support ignores delete_grants; make sure the
+	// grants are really gone instead of silently leaving access in place.
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not verify grant revocation on cluster %d: %s", clusterID, err))
+		return
+	}
+	if remaining := remainingGrants(managed.grants, currentConfig(cluster).grants); len(remaining) > 0 {
+		var names []string
+		for _, g := range remaining {
+			names = append(names, fmt.Sprintf("%s on %s", g.Username, g.Database))
+		}
+		sort.Strings(names)
+		resp.Diagnostics.AddWarning("Grants not revoked",
+			fmt.Sprintf("%s still have access on cluster %d after the revocation request. "+
+				"The grants remain on the cluster but are removed from Terraform state.", strings.Join(names, ", "), clusterID))
+	}
+}
+
+func (r *clusterConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedKey, []byte("true"))...)
+}
+
+// apply diffs the planned configuration against the cluster and sends the
+// difference as a single configure request. Passwords of newly created users
+// are added to plan.Passwords.
+func (r *clusterConfigResource) apply(ctx context.Context, clusterID int, plan *clusterConfigResourceModel, diags *diag.Diagnostics) {
+	desired, d := configFromModel(ctx, *plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		diags.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	configReq := diffConfig(desired, currentConfig(cluster))
+	if isEmptyConfigureRequest(configReq) {
+		return
+	}
+
+	tflog.Info(ctx, "Applying cluster access configuration", map[string]interface{}{
+		"cluster_id":       clusterID,
+		"users":            len(configReq.Users),
+		"delete_users":     configReq.DeleteUsers,
+		"databases":        len(configReq.Databases),
+		"delete_databases": configReq.DeleteDatabases,
+		"grants":           len(configReq.Grants),
+		"delete_grants":    len(configReq.DeleteGrants),
+		"extensions":       len(configReq.Extensions),
+		"delete_ips":       configReq.DeleteIPs,
+	})
+
+	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, configReq, 2*time.Minute)
+	if err != nil {
+		diags.AddError("Error configuring cluster",
+			fmt.Sprintf("Could not apply access configuration to cluster %d: %s", clusterID, err))
+		return
+	}
+
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, clusterID, 10*time.Minute); err != nil {
+			diags.AddError("Error waiting for cluster configuration",
+				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+			return
+		}
+	}
+
+	passwords := make(map[string]attr.Value)
+	for name, v := range plan.Passwords.Elements() {
+		passwords[name] = v
+	}
+	for _, name := range configReq.DeleteUsers {
+		delete(passwords, name)
+	}
+	for _, u := range configResp.Users {
+		passwords[u.Username] = types.StringValue(u.Password)
+	}
+	plan.Passwords = types.MapValueMust(types.StringType, passwords)
+}
+
+// diffConfig builds the configure request that turns current into desired.
+// Kinds of objects that are not managed in desired are left alone.
+func diffConfig(desired, current accessConfig) client.ConfigureRequest {
+	var req client.ConfigureRequest
+
+	if desired.users != nil {
+		for _, u := range difference(desired.users, current.users) {
+			req.Users = append(req.Users, client.ConfigUserRequest{Username: u})
+		}
+		req.DeleteUsers = difference(current.users, desired.users)
+	}
+
+	if desired.databases != nil {
+		existing := make(map[string]bool, len(current.databases))
+		for _, db := range current.databases {
+			existing[db.DBName] = true
+		}
+		wanted := make(map[string]bool, len(desired.databases))
+		for _, db := range desired.databases {
+			wanted[db.DBName] = true
+			if !existing[db.DBName] {
+				req.Databases = append(req.Databases, client.ConfigDatabaseRequest{Name: db.DBName, Owner: db.Owner})
+			}
+		}
+		for _, db := range current.databases {
+			if !wanted[db.DBName] {
+				req.DeleteDatabases = append(req.DeleteDatabases, db.DBName)
+			}
+		}
+	}
+
+	if desired.grants != nil {
+		existing := make(map[string]bool, len(current.grants))
+		for _, g := range current.grants {
+			existing[grantKey(g)] = true
+		}
+		for _, g := range desired.grants {
+			if !existing[grantKey(g)] {
+				req.Grants = append(req.Grants, client.ConfigGrantRequest{Username: g.Username, Database: g.Database, Access: g.Access})
+			}
+		}
+		// Access level changes are applied in place, so only grants whose
+		// user and database are no longer wanted are revoked.
+		for _, g := range missingGrants(current.grants, desired.grants) {
+			req.DeleteGrants = append(req.DeleteGrants, client.ConfigGrantRequest{Username: g.Username, Database: g.Database})
+		}
+	}
+
+	if desired.extensions != nil {
+		existing := make(map[string]bool, len(current.extensions))
+		for _, ext := range current.extensions {
+			existing[ext.Database+"/"+ext.Extension] = true
+		}
+		for _, ext := range desired.extensions {
+			if !existing[ext.Database+"/"+ext.Extension] {
+				req.Extensions = append(req.Extensions, client.ConfigExtensionRequest{Extension: ext.Extension, Database: ext.Database})
+			}
+		}
+	}
+
+	if desired.cidrs != nil && (len(difference(desired.cidrs, current.cidrs)) > 0 || len(difference(current.cidrs, desired.cidrs)) > 0) {
+		req.SourceIPs = desired.cidrs
+		req.DeleteIPs = difference(current.cidrs, desired.cidrs)
+		req.ReplaceIPs = true
+	}
+
+	return req
+}
+
+// currentConfig returns the access objects of a cluster, excluding the
+// default user and database that are created with the cluster.
+func currentConfig(c *client.Cluster) accessConfig {
+	cfg := accessConfig{
+		users:      []string{},
+		databases:  []client.ClusterDatabase{},
+		grants:     []client.ClusterGrant{},
+		extensions: []client.ClusterExtension{},
+		cidrs:      []string{},
+	}
+	for _, u := range c.Users {
+		if u.Username != c.DBUser {
+			cfg.users = append(cfg.users, u.Username)
+		}
+	}
+	for _, db := range c.Databases {
+		if db.DBName != c.DBName {
+			cfg.databases = append(cfg.databases, db)
+		}
+	}
+	// Only database-wide read/write grants are managed here; schema- and
+	// object-level grants and explicit privileges belong to
+	// rivestack_cluster_grant.
+	for _, g := range c.Grants {
+		if g.DatabaseWide() && g.Access != "" {
+			cfg.grants = append(cfg.grants, g)
+		}
+	}
+	for _, ext := range c.Extensions {
+		if ext.Database == "" {
+			ext.Database = c.DBName
+		}
+		cfg.extensions = append(cfg.extensions, ext)
+	}
+	cfg.cidrs = append(cfg.cidrs, c.AllowedIPs()...)
+	return cfg
+}
+
+// configFromModel converts the resource model into an accessConfig. Null
+// attributes become nil slices, meaning that kind of object is unmanaged.
+func configFromModel(ctx context.Context, m clusterConfigResourceModel) (accessConfig, diag.Diagnostics) {
+	var cfg accessConfig
+	var diags diag.Diagnostics
+
+	if !m.Users.IsNull() && !m.Users.IsUnknown() {
+		cfg.users = []string{}
+		diags.Append(m.Users.ElementsAs(ctx, &cfg.users, false)...)
+	}
+	if !m.Databases.IsNull() && !m.Databases.IsUnknown() {
+		var dbs []databaseModel
+		diags.Append(m.Databases.ElementsAs(ctx, &dbs, false)...)
+		cfg.databases = []client.ClusterDatabase{}
+		for _, db := range dbs {
+			cfg.databases = append(cfg.databases, client.ClusterDatabase{DBName: db.Name.ValueString(), Owner: db.Owner.ValueString()})
+		}
+	}
+	if !m.Grants.IsNull() && !m.Grants.IsUnknown() {
+		var grants []grantModel
+		diags.Append(m.Grants.ElementsAs(ctx, &grants, false)...)
+		cfg.grants = []client.ClusterGrant{}
+		for _, g := range grants {
+			cfg.grants = append(cfg.grants, client.ClusterGrant{
+				Username: g.Username.ValueString(),
+				Database: g.Database.ValueString(),
+				Access:   g.Access.ValueString(),
+			})
+		}
+	}
+	if !m.Extensions.IsNull() && !m.Extensions.IsUnknown() {
+		var exts []extensionModel
+		diags.Append(m.Extensions.ElementsAs(ctx, &exts, false)...)
+		cfg.extensions = []client.ClusterExtension{}
+		for _, ext := range exts {
+			cfg.extensions = append(cfg.extensions, client.ClusterExtension{
+				Extension: ext.Extension.ValueString(),
+				Database:  ext.Database.ValueString(),
+			})
+		}
+	}
+	if !m.AllowedCIDRs.IsNull() && !m.AllowedCIDRs.IsUnknown() {
+		cfg.cidrs = []string{}
+		diags.Append(m.AllowedCIDRs.ElementsAs(ctx, &cfg.cidrs, false)...)
+	}
+	return cfg, diags
+}
+
+// priorOwners returns the owners of the databases in state, keyed by name.
+// Owners only apply when a database is created, so Read keeps these instead
+// of reporting the API's owner as drift.
+func priorOwners(ctx context.Context, dbs types.Set) map[string]types.String {
+	owners := make(map[string]types.String)
+	if dbs.IsNull() || dbs.IsUnknown() {
+		return owners
+	}
+	var models []databaseModel
+	_ = dbs.ElementsAs(ctx, &models, false)
+	for _, db := range models {
+		owners[db.Name.ValueString()] = db.Owner
+	}
+	return owners
+}
+
+func databasesToSet(dbs []client.ClusterDatabase, prior map[string]types.String) types.Set {
+	elems := make([]attr.Value, 0, len(dbs))
+	for _, db := range dbs {
+		owner, ok := prior[db.DBName]
+		if !ok {
+			owner = types.StringValue(db.Owner)
+		}
+		elems = append(elems, types.ObjectValueMust(databaseAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(db.DBName),
+			"owner": owner,
+		}))
+	}
+	return types.SetValueMust(types.ObjectType{AttrTypes: databaseAttrTypes}, elems)
+}
+
+func grantsToSet(grants []client.ClusterGrant) types.Set {
+	elems := make([]attr.Value, 0, len(grants))
+	for _, g := range grants {
+		elems = append(elems, types.ObjectValueMust(grantAttrTypes, map[string]attr.Value{
+			"username": types.StringValue(g.Username),
+			"database": types.StringValue(g.Database),
+			"access":   types.StringValue(g.Access),
+		}))
+	}
+	return types.SetValueMust(types.ObjectType{AttrTypes: grantAttrTypes}, elems)
+}
+
+func extensionsToSet(exts []client.ClusterExtension) types.Set {
+	elems := make([]attr.Value, 0, len(exts))
+	for _, ext := range exts {
+		elems = append(elems, types.ObjectValueMust(extensionAttrTypes, map[string]attr.Value{
+			"extension": types.StringValue(ext.Extension),
+			"database":  types.StringValue(ext.Database),
+		}))
+	}
+	return types.SetValueMust(types.ObjectType{AttrTypes: extensionAttrTypes}, elems)
+}
+
+func stringSet(values []string) types.Set {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func grantKey(g client.ClusterGrant) string {
+	return g.Username + "/" + g.Database + "/" + g.Access
+}
+
+// missingGrants returns the grants in a whose user and database have no
+// grant in b.
+func missingGrants(a, b []client.ClusterGrant) []client.ClusterGrant {
+	wanted := make(map[string]bool, len(b))
+	for _, g := range b {
+		wanted[g.Username+"/"+g.Database] = true
+	}
+	var missing []client.ClusterGrant
+	for _, g := range a {
+		if !wanted[g.Username+"/"+g.Database] {
+			missing = append(missing, g)
+		}
+	}
+	return missing
+}
+
+// changedOwners describes the databases in desired that exist in current with
+// a different configured owner.
+func changedOwners(current, desired []client.ClusterDatabase) []string {
+	owners := make(map[string]string, len(current))
+	for _, db := range current {
+		owners[db.DBName] = db.Owner
+	}
+	var changed []string
+	for _, db := range desired {
+		if owner, ok := owners[db.DBName]; ok && owner != db.Owner {
+			changed = append(changed, db.DBName)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// installedExtensions returns the extensions in tracked that are installed.
+func installedExtensions(installed, tracked []client.ClusterExtension) []client.ClusterExtension {
+	present := make(map[string]bool, len(installed))
+	for _, ext := range installed {
+		present[ext.Database+"/"+ext.Extension] = true
+	}
+	var exts []client.ClusterExtension
+	for _, ext := range tracked {
+		if present[ext.Database+"/"+ext.Extension] {
+			exts = append(exts, ext)
+		}
+	}
+	return exts
+}
+
+// remainingGrants returns the grants in a whose user and database still have
+// a grant in b.
+func remainingGrants(a, b []client.ClusterGrant) []client.ClusterGrant {
+	present := make(map[string]bool, len(b))
+	for _, g := range b {
+		present[g.Username+"/"+g.Database] = true
+	}
+	var remaining []client.ClusterGrant
+	for _, g := range a {
+		if present[g.Username+"/"+g.Database] {
+			remaining = append(remaining, g)
+		}
+	}
+	return remaining
+}
+
+func missingExtensions(a, b []client.ClusterExtension) []string {
+	wanted := make(map[string]bool, len(b))
+	for _, ext := range b {
+		wanted[ext.Database+"/"+ext.Extension] = true
+	}
+	var missing []string
+	for _, ext := range a {
+		if !wanted[ext.Database+"/"+ext.Extension] {
+			missing = append(missing, fmt.Sprintf("%s on %s", ext.Extension, ext.Database))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// difference returns the values of a that are not in b.
+func difference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, v := range b {
+		seen[v] = true
+	}
+	var diff []string
+	for _, v := range a {
+		if !seen[v] {
+			diff = append(diff, v)
+		}
+	}
+	return diff
+}
+
+func isEmptyConfigureRequest(req client.ConfigureRequest) bool {
+	return len(req.Users) == 0 && len(req.DeleteUsers) == 0 &&
+		len(req.Databases) == 0 && len(req.DeleteDatabases) == 0 &&
+		len(req.Grants) == 0 && len(req.DeleteGrants) == 0 && len(req.Extensions) == 0 &&
+		len(req.SourceIPs) == 0 && len(req.DeleteIPs) == 0 && !req.ReplaceIPs
+}
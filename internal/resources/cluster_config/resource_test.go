@@ -0,0 +1,224 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+func TestCurrentConfig(t *testing.T) {
+	cluster := &client.Cluster{
+		DBName:    "app",
+		DBUser:    "admin",
+		SourceIPs: "10.0.0.0/8, 192.168.1.0/24",
+		Users:     []client.ClusterUser{{Username: "admin"}, {Username: "reader"}},
+		Databases: []client.ClusterDatabase{{DBName: "app", Owner: "admin"}, {DBName: "reports", Owner: "reader"}},
+		Grants: []client.ClusterGrant{
+			{Username: "reader", Database: "app", Access: "read"},
+			{Username: "reader", Database: "app", ObjectType: "database", Access: "write"},
+			{Username: "reader", Database: "app", ObjectType: "schema", Schema: "public", Access: "read"},
+			{Username: "reader", Database: "reports", Privileges: []string{"CONNECT"}},
+		},
+		Extensions: []client.ClusterExtension{{Extension: "vector"}, {Extension: "postgis", Database: "reports"}},
+	}
+
+	got := currentConfig(cluster)
+
+	if want := []string{"reader"}; !reflect.DeepEqual(got.users, want) {
+		t.Errorf("expected users %v, got %v", want, got.users)
+	}
+	if want := []client.ClusterDatabase{{DBName: "reports", Owner: "reader"}}; !reflect.DeepEqual(got.databases, want) {
+		t.Errorf("expected databases %v, got %v", want, got.databases)
+	}
+	if len(got.grants) != 2 || got.grants[0].Access != "read" || got.grants[1].Access != "write" {
+		t.Errorf("expected the two database-wide grants, got %+v", got.grants)
+	}
+	wantExts := []client.ClusterExtension{{Extension: "vector", Database: "app"}, {Extension: "postgis", Database: "reports"}}
+	if !reflect.DeepEqual(got.extensions, wantExts) {
+		t.Errorf("expected extensions %v, got %v", wantExts, got.extensions)
+	}
+	if want := []string{"10.0.0.0/8", "192.168.1.0/24"}; !reflect.DeepEqual(got.cidrs, want) {
+		t.Errorf("expected cidrs %v, got %v", want, got.cidrs)
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	current := currentConfig(&client.Cluster{
+		DBName:     "app",
+		DBUser:     "admin",
+		SourceIPs:  "10.0.0.0/8,192.168.1.0/24",
+		Users:      []client.ClusterUser{{Username: "admin"}, {Username: "reader"}, {Username: "legacy"}},
+		Databases:  []client.ClusterDatabase{{DBName: "app", Owner: "admin"}, {DBName: "reports", Owner: "reader"}},
+		Grants:     []client.ClusterGrant{{Username: "reader", Database: "reports", Access: "read"}},
+		Extensions: []client.ClusterExtension{{Extension: "vector"}},
+	})
+
+	tests := []struct {
+		name    string
+		desired accessConfig
+		want    client.ConfigureRequest
+	}{
+		{
+			name:    "unmanaged kinds are left alone",
+			desired: accessConfig{},
+			want:    client.ConfigureRequest{},
+		},
+		{
+			name:    "default user and database are never deleted",
+			desired: accessConfig{users: []string{}, databases: []client.ClusterDatabase{}},
+			want: client.ConfigureRequest{
+				DeleteUsers:     []string{"reader", "legacy"},
+				DeleteDatabases: []string{"reports"},
+			},
+		},
+		{
+			name: "users and databases are added",
+			desired: accessConfig{
+				users:     []string{"reader", "legacy", "writer"},
+				databases: []client.ClusterDatabase{{DBName: "reports", Owner: "reader"}, {DBName: "events", Owner: "writer"}},
+			},
+			want: client.ConfigureRequest{
+				Users:     []client.ConfigUserRequest{{Username: "writer"}},
+				Databases: []client.ConfigDatabaseRequest{{Name: "events", Owner: "writer"}},
+			},
+		},
+		{
+			name:    "access level change is upserted, not revoked",
+			desired: accessConfig{grants: []client.ClusterGrant{{Username: "reader", Database: "reports", Access: "write"}}},
+			want: client.ConfigureRequest{
+				Grants: []client.ConfigGrantRequest{{Username: "reader", Database: "reports", Access: "write"}},
+			},
+		},
+		{
+			name:    "removed grant is revoked",
+			desired: accessConfig{grants: []client.ClusterGrant{}},
+			want: client.ConfigureRequest{
+				DeleteGrants: []client.ConfigGrantRequest{{Username: "reader", Database: "reports"}},
+			},
+		},
+		{
+			name:    "installed extension is not reinstalled",
+			desired: accessConfig{extensions: []client.ClusterExtension{{Extension: "vector", Database: "app"}, {Extension: "postgis", Database: "app"}}},
+			want: client.ConfigureRequest{
+				Extensions: []client.ConfigExtensionRequest{{Extension: "postgis", Database: "app"}},
+			},
+		},
+		{
+			name:    "same CIDR set in a different order",
+			desired: accessConfig{cidrs: []string{"192.168.1.0/24", "10.0.0.0/8"}},
+			want:    client.ConfigureRequest{},
+		},
+		{
+			name:    "changed CIDR set is replaced",
+			desired: accessConfig{cidrs: []string{"10.0.0.0/8", "172.16.0.0/12"}},
+			want: client.ConfigureRequest{
+				SourceIPs:  []string{"10.0.0.0/8", "172.16.0.0/12"},
+				DeleteIPs:  []string{"192.168.1.0/24"},
+				ReplaceIPs: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffConfig(tt.desired, current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdate_AddUser(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/ha/42":
+			_ = json.NewEncoder(w).Encode(client.Cluster{
+				ID:     42,
+				DBName: "app",
+				DBUser: "admin",
+				Users:  []client.ClusterUser{{Username: "admin"}, {Username: "reader"}},
+			})
+		case "/api/ha/42/configure":
+			var req client.ConfigureRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Users) != 1 || req.Users[0].Username != "writer" {
+				t.Errorf("expected user writer to be created, got %+v", req.Users)
+			}
+			_ = json.NewEncoder(w).Encode(client.ConfigureResponse{
+				Users: []client.ConfigUserResponse{{Username: "writer", Password: "s3cret"}},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	r := &clusterConfigResource{client: client.NewClient(server.URL, "rsk_test", "1.0.0")}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := func(users []string, passwords map[string]attr.Value) clusterConfigResourceModel {
+		return clusterConfigResourceModel{
+			ID:           types.StringValue("42"),
+			ClusterID:    types.StringValue("42"),
+			Users:        stringSet(users),
+			Databases:    types.SetNull(types.ObjectType{AttrTypes: databaseAttrTypes}),
+			Grants:       types.SetNull(types.ObjectType{AttrTypes: grantAttrTypes}),
+			Extensions:   types.SetNull(types.ObjectType{AttrTypes: extensionAttrTypes}),
+			AllowedCIDRs: types.SetNull(types.StringType),
+			Passwords:    types.MapValueMust(types.StringType, passwords),
+		}
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, model([]string{"reader"}, map[string]attr.Value{"reader": types.StringValue("old")})); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	// UseStateForUnknown copies the prior passwords into the proposed plan.
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, model([]string{"reader", "writer"}, map[string]attr.Value{"reader": types.StringValue("old")})); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	modifyResp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", modifyResp.Diagnostics)
+	}
+	var planned types.Map
+	modifyResp.Plan.GetAttribute(ctx, path.Root("passwords"), &planned)
+	if !planned.IsUnknown() {
+		t.Fatalf("expected passwords to be unknown in the plan, got %s", planned)
+	}
+
+	updateResp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: modifyResp.Plan, State: state}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+	var got clusterConfigResourceModel
+	updateResp.State.Get(ctx, &got)
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"reader": types.StringValue("old"),
+		"writer": types.StringValue("s3cret"),
+	})
+	if !got.Passwords.Equal(want) {
+		t.Errorf("expected passwords %s, got %s", want, got.Passwords)
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_connection_pool
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+var (
+	_ resource.Resource                = &clusterConnectionPoolResource{}
+	_ resource.ResourceWithImportState = &clusterConnectionPoolResource{}
+)
+
+func NewResource() resource.Resource {
+	return &clusterConnectionPoolResource{}
+}
+
+type clusterConnectionPoolResource struct {
+	client *client.Client
+}
+
+type clusterConnectionPoolResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	ClusterID              types.String `tfsdk:"cluster_id"`
+	PoolMode               types.String `tfsdk:"pool_mode"`
+	DefaultPoolSize        types.Int64  `tfsdk:"default_pool_size"`
+	MaxClientConn          types.Int64  `tfsdk:"max_client_conn"`
+	Databases              types.List   `tfsdk:"databases"`
+	Host                   types.String `tfsdk:"host"`
+	Port                   types.Int64  `tfsdk:"port"`
+	PooledConnectionString types.String `tfsdk:"pooled_connection_string"`
+	UpdatedAt              types.String `tfsdk:"updated_at"`
+}
+
+type poolDatabaseModel struct {
+	Database types.String `tfsdk:"database"`
+	PoolMode types.String `tfsdk:"pool_mode"`
+	PoolSize types.Int64  `tfsdk:"pool_size"`
+}
+
+var poolDatabaseAttrTypes = map[string]attr.Type{
+	"database":  types.StringType,
+	"pool_mode": types.StringType,
+	"pool_size": types.Int64Type,
+}
+
+func (r *clusterConnectionPoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_connection_pool"
+}
+
+func (r *clusterConnectionPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	poolModes := []string{"session", "transaction", "statement"}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the built-in connection pooler (PgBouncer) of a Rivestack HA PostgreSQL cluster. Destroying this resource disables the pooler.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (cluster_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pool_mode": schema.StringAttribute{
+				Description: "Default pool mode: session, transaction, or statement.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("transaction"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(poolModes...),
+				},
+			},
+			"default_pool_size": schema.Int64Attribute{
+				Description: "Number of server connections per user/database pair.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_client_conn": schema.Int64Attribute{
+				Description: "Maximum number of client connections accepted by the pooler.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"databases": schema.ListNestedAttribute{
+				Description: "Per-database pool overrides.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"database": schema.StringAttribute{
+							Description: "Database name.",
+							Required:    true,
+						},
+						"pool_mode": schema.StringAttribute{
+							Description: "Pool mode for this database. Defaults to the cluster-wide pool_mode.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(poolModes...),
+							},
+						},
+						"pool_size": schema.Int64Attribute{
+							Description: "Pool size for this database. Defaults to default_pool_size.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"host": schema.StringAttribute{
+				Description: "Pooler hostname.",
+				Computed:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "Pooler port.",
+				Computed:    true,
+			},
+			"pooled_connection_string": schema.StringAttribute{
+				Description: "PostgreSQL connection string that goes through the pooler, including the sslmode matching the cluster's require_ssl.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Last update timestamp.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *clusterConnectionPoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *clusterConnectionPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clusterConnectionPoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	updateReq, diags := buildUpdateRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Enabling cluster connection pool", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	pool, err := r.client.UpdateConnectionPool(ctx, clusterID, updateReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error enabling connection pool",
+			fmt.Sprintf("Could not enable connection pool on cluster %d: %s", clusterID, err))
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	mapConnectionPoolToState(pool, cluster, &plan)
+	plan.ID = types.StringValue(plan.ClusterID.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterConnectionPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clusterConnectionPoolResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	pool, err := r.client.GetConnectionPool(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading connection pool",
+			fmt.Sprintf("Could not read connection pool for cluster %d: %s", clusterID, err))
+		return
+	}
+
+	if !pool.Enabled {
+		tflog.Warn(ctx, "Connection pool is disabled, removing from state", map[string]interface{}{
+			"cluster_id": clusterID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	mapConnectionPoolToState(pool, cluster, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *clusterConnectionPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan clusterConnectionPoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	updateReq, diags := buildUpdateRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Updating cluster connection pool", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	pool, err := r.client.UpdateConnectionPool(ctx, clusterID, updateReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating connection pool",
+			fmt.Sprintf("Could not update connection pool on cluster %d: %s", clusterID, err))
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	mapConnectionPoolToState(pool, cluster, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterConnectionPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state clusterConnectionPoolResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Disabling cluster connection pool", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	enabled := false
+	_, err = r.client.UpdateConnectionPool(ctx, clusterID, client.UpdateConnectionPoolRequest{
+		Enabled:   &enabled,
+		Databases: []client.ConnectionPoolDatabase{},
+	})
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error disabling connection pool",
+			fmt.Sprintf("Could not disable connection pool on cluster %d: %s", clusterID, err))
+		return
+	}
+}
+
+func (r *clusterConnectionPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), req.ID)...)
+}
+
+func buildUpdateRequest(ctx context.Context, plan clusterConnectionPoolResourceModel) (client.UpdateConnectionPoolRequest, diag.Diagnostics) {
+	enabled := true
+	req := client.UpdateConnectionPoolRequest{
+		Enabled:   &enabled,
+		PoolMode:  plan.PoolMode.ValueString(),
+		Databases: []client.ConnectionPoolDatabase{},
+	}
+	if !plan.DefaultPoolSize.IsNull() && !plan.DefaultPoolSize.IsUnknown() {
+		size := int(plan.DefaultPoolSize.ValueInt64())
+		req.DefaultPoolSize = &size
+	}
+	if !plan.MaxClientConn.IsNull() && !plan.MaxClientConn.IsUnknown() {
+		maxConn := int(plan.MaxClientConn.ValueInt64())
+		req.MaxClientConn = &maxConn
+	}
+
+	var diags diag.Diagnostics
+	if !plan.Databases.IsNull() && !plan.Databases.IsUnknown() {
+		var dbs []poolDatabaseModel
+		diags.Append(plan.Databases.ElementsAs(ctx, &dbs, false)...)
+		for _, db := range dbs {
+			req.Databases = append(req.Databases, client.ConnectionPoolDatabase{
+				Database: db.Database.ValueString(),
+				PoolMode: db.PoolMode.ValueString(),
+				PoolSize: int(db.PoolSize.ValueInt64()),
+			})
+		}
+	}
+	return req, diags
+}
+
+// mapConnectionPoolToState copies the pool into state. The cluster supplies
+// require_ssl so the pooled connection string matches the one on the cluster.
+func mapConnectionPoolToState(pool *client.ConnectionPool, cluster *client.Cluster, state *clusterConnectionPoolResourceModel) {
+	state.PoolMode = types.StringValue(pool.PoolMode)
+	state.DefaultPoolSize = types.Int64Value(int64(pool.DefaultPoolSize))
+	state.MaxClientConn = types.Int64Value(int64(pool.MaxClientConn))
+	state.Host = types.StringValue(pool.Host)
+	state.Port = types.Int64Value(int64(pool.Port))
+	state.PooledConnectionString = types.StringValue(client.WithSSLMode(pool.PooledConnectionString, cluster.RequireSSL))
+	state.UpdatedAt = types.StringValue(pool.UpdatedAt.Format(time.RFC3339))
+
+	// Keep databases null when none are configured so an omitted attribute
+	// does not show a diff against an empty list.
+	if len(pool.Databases) == 0 && state.Databases.IsNull() {
+		return
+	}
+	elemType := types.ObjectType{AttrTypes: poolDatabaseAttrTypes}
+	elems := make([]attr.Value, 0, len(pool.Databases))
+	for _, db := range pool.Databases {
+		poolMode := types.StringNull()
+		if db.PoolMode != "" {
+			poolMode = types.StringValue(db.PoolMode)
+		}
+		poolSize := types.Int64Null()
+		if db.PoolSize > 0 {
+			poolSize = types.Int64Value(int64(db.PoolSize))
+		}
+		elems = append(elems, types.ObjectValueMust(poolDatabaseAttrTypes, map[string]attr.Value{
+			"database":  types.StringValue(db.Database),
+			"pool_mode": poolMode,
+			"pool_size": poolSize,
+		}))
+	}
+	state.Databases = types.ListValueMust(elemType, elems)
+}
@@ -6,14 +6,18 @@ package cluster_grant
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -25,10 +29,22 @@ import (
 )
 
 var (
-	_ resource.Resource                = &clusterGrantResource{}
-	_ resource.ResourceWithImportState = &clusterGrantResource{}
+	_ resource.Resource                   = &clusterGrantResource{}
+	_ resource.ResourceWithImportState    = &clusterGrantResource{}
+	_ resource.ResourceWithModifyPlan     = &clusterGrantResource{}
+	_ resource.ResourceWithValidateConfig = &clusterGrantResource{}
 )
 
+// privilegesByObjectType lists the PostgreSQL privileges that can be granted
+// on each object type.
+var privilegesByObjectType = map[string][]string{
+	"database": {"CONNECT", "CREATE", "TEMPORARY"},
+	"schema":   {"USAGE", "CREATE"},
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"sequence": {"USAGE", "SELECT", "UPDATE"},
+	"function": {"EXECUTE"},
+}
+
 func NewResource() resource.Resource {
 	return &clusterGrantResource{}
 }
@@ -38,11 +54,26 @@ type clusterGrantResource struct {
 }
 
 type clusterGrantResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	ClusterID types.String `tfsdk:"cluster_id"`
-	Username  types.String `tfsdk:"username"`
-	Database  types.String `tfsdk:"database"`
-	Access    types.String `tfsdk:"access"`
+	ID              types.String `tfsdk:"id"`
+	ClusterID       types.String `tfsdk:"cluster_id"`
+	Username        types.String `tfsdk:"username"`
+	Database        types.String `tfsdk:"database"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Schema          types.String `tfsdk:"schema"`
+	ObjectName      types.String `tfsdk:"object_name"`
+	Access          types.String `tfsdk:"access"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+}
+
+// grantTarget identifies a grant on a cluster.
+type grantTarget struct {
+	clusterID  int
+	username   string
+	database   string
+	objectType string
+	schema     string
+	objectName string
 }
 
 func (r *clusterGrantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,10 +82,10 @@ func (r *clusterGrantResource) Metadata(_ context.Context, req resource.Metadata
 
 func (r *clusterGrantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages an access grant on a Rivestack HA PostgreSQL cluster. Note: grant revocation is not currently supported by the API; destroying this resource removes it from Terraform state only.",
+		Description: "Manages an access grant on a Rivestack HA PostgreSQL cluster. Grants cover a whole database by default, or a schema, table, sequence or function when object_type is set. Destroying this resource revokes the grant.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Resource identifier (cluster_id/username/database).",
+				Description: "Resource identifier (cluster_id/username/database for database grants, cluster_id/username/database/object_type/schema[/object_name] otherwise).",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -81,15 +112,56 @@ func (r *clusterGrantResource) Schema(_ context.Context, _ resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"object_type": schema.StringAttribute{
+				Description: "Kind of object the grant applies to: database, schema, table, sequence or function. Defaults to database.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("database"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("database", "schema", "table", "sequence", "function"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "Schema the grant applies to. Required unless object_type is database.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_name": schema.StringAttribute{
+				Description: "Name of the table, sequence or function. Functions include their argument types (e.g., calc_total(integer)). Omit to grant on all objects of object_type in the schema.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"access": schema.StringAttribute{
-				Description: "Access level: read (SELECT only) or write (SELECT, INSERT, UPDATE, DELETE).",
+				Description: "Access level on the tables covered by a database or table grant: read (SELECT only) or write (SELECT, INSERT, UPDATE, DELETE). Defaults to write unless privileges is set.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("write"),
 				Validators: []validator.String{
 					stringvalidator.OneOf("read", "write"),
+					stringvalidator.ConflictsWith(path.MatchRoot("privileges")),
 				},
 			},
+			"privileges": schema.SetAttribute{
+				Description: "Explicit privileges to grant instead of access. Database: CONNECT, CREATE, TEMPORARY. Schema: USAGE, CREATE. " +
+					"Table: SELECT, INSERT, UPDATE, DELETE, TRUNCATE, REFERENCES, TRIGGER. Sequence: USAGE, SELECT, UPDATE. Function: EXECUTE.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				Description: "Allow the user to grant the same access to other users (WITH GRANT OPTION). Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -107,6 +179,81 @@ func (r *clusterGrantResource) Configure(_ context.Context, req resource.Configu
 	r.client = c
 }
 
+func (r *clusterGrantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config clusterGrantResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ObjectType.IsUnknown() {
+		return
+	}
+
+	objectType := "database"
+	if !config.ObjectType.IsNull() {
+		objectType = config.ObjectType.ValueString()
+	}
+
+	if objectType == "database" && !config.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid grant",
+			"schema cannot be set for database grants.")
+	}
+	if objectType != "database" && config.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid grant",
+			fmt.Sprintf("schema is required for %s grants.", objectType))
+	}
+	if (objectType == "database" || objectType == "schema") && !config.ObjectName.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("object_name"), "Invalid grant",
+			fmt.Sprintf("object_name cannot be set for %s grants.", objectType))
+	}
+
+	tableAccess := objectType == "database" || objectType == "table"
+	if !config.Access.IsNull() && !tableAccess {
+		resp.Diagnostics.AddAttributeError(path.Root("access"), "Invalid grant",
+			fmt.Sprintf("access only applies to database and table grants. Use privileges for %s grants.", objectType))
+	}
+	if config.Privileges.IsNull() {
+		if config.Access.IsNull() && !tableAccess {
+			resp.Diagnostics.AddAttributeError(path.Root("privileges"), "Invalid grant",
+				fmt.Sprintf("privileges is required for %s grants.", objectType))
+		}
+		return
+	}
+	if config.Privileges.IsUnknown() {
+		return
+	}
+
+	var privileges []types.String
+	resp.Diagnostics.Append(config.Privileges.ElementsAs(ctx, &privileges, false)...)
+	allowed := privilegesByObjectType[objectType]
+	for _, p := range privileges {
+		if p.IsUnknown() || p.IsNull() {
+			continue
+		}
+		if !slices.Contains(allowed, p.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("privileges"), "Invalid privilege",
+				fmt.Sprintf("%q cannot be granted on a %s. Valid privileges: %s.",
+					p.ValueString(), objectType, strings.Join(allowed, ", ")))
+		}
+	}
+}
+
+func (r *clusterGrantResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config clusterGrantResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || !config.Access.IsNull() || config.Privileges.IsUnknown() {
+		return
+	}
+
+	// access defaults to write only for grants without explicit privileges.
+	access := types.StringNull()
+	if config.Privileges.IsNull() {
+		access = types.StringValue("write")
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("access"), access)...)
+}
+
 func (r *clusterGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan clusterGrantResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -114,44 +261,47 @@ func (r *clusterGrantResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	target, err := targetFromModel(&plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid cluster ID",
 			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
 		return
 	}
 
-	grantReq := client.ConfigGrantRequest{
-		Username: plan.Username.ValueString(),
-		Database: plan.Database.ValueString(),
-		Access:   plan.Access.ValueString(),
+	grantReq, diags := grantRequest(ctx, target, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	tflog.Info(ctx, "Creating cluster grant", map[string]interface{}{
-		"cluster_id": clusterID,
-		"username":   plan.Username.ValueString(),
-		"database":   plan.Database.ValueString(),
-		"access":     plan.Access.ValueString(),
+		"cluster_id":  target.clusterID,
+		"username":    target.username,
+		"database":    target.database,
+		"object_type": target.objectType,
+		"access":      grantReq.Access,
+		"privileges":  grantReq.Privileges,
 	})
 
-	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, client.ConfigureRequest{
+	configResp, err := r.client.ConfigureWithRetry(ctx, target.clusterID, client.ConfigureRequest{
 		Grants: []client.ConfigGrantRequest{grantReq},
 	}, 2*time.Minute)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating cluster grant",
-			fmt.Sprintf("Could not create grant on cluster %d: %s", clusterID, err))
+			fmt.Sprintf("Could not create grant on cluster %d: %s", target.clusterID, err))
 		return
 	}
 
 	if configResp.JobID > 0 {
-		if err := r.client.WaitForJobComplete(ctx, clusterID, 5*time.Minute); err != nil {
+		if err := r.client.WaitForJobComplete(ctx, target.clusterID, 5*time.Minute); err != nil {
 			resp.Diagnostics.AddError("Error waiting for grant creation",
-				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+				fmt.Sprintf("Configure job failed for cluster %d: %s", target.clusterID, err))
 			return
 		}
 	}
 
-	plan.ID = types.StringValue(fmt.Sprintf("%d/%s/%s", clusterID, plan.Username.ValueString(), plan.Database.ValueString()))
+	plan.ID = types.StringValue(target.id())
+	plan.Access = stringOrNull(grantReq.Access)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -163,43 +313,50 @@ func (r *clusterGrantResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	clusterID, username, database, err := parseGrantID(state.ID.ValueString())
+	target, err := parseGrantID(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid resource ID",
 			fmt.Sprintf("Could not parse resource ID %q: %s", state.ID.ValueString(), err))
 		return
 	}
 
-	cluster, err := r.client.GetCluster(ctx, clusterID)
+	cluster, err := r.client.GetCluster(ctx, target.clusterID)
 	if err != nil {
 		if client.IsNotFound(err) || client.IsGone(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
 		resp.Diagnostics.AddError("Error reading cluster",
-			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+			fmt.Sprintf("Could not read cluster %d: %s", target.clusterID, err))
 		return
 	}
 
-	found := false
-	for _, g := range cluster.Grants {
-		if g.Username == username && g.Database == database {
-			state.Access = types.StringValue(g.Access)
-			found = true
-			break
-		}
-	}
-
-	if !found {
+	grant := target.find(cluster.Grants)
+	if grant == nil {
 		tflog.Warn(ctx, "Cluster grant not found, removing from state", map[string]interface{}{
-			"cluster_id": clusterID,
-			"username":   username,
-			"database":   database,
+			"cluster_id":  target.clusterID,
+			"username":    target.username,
+			"database":    target.database,
+			"object_type": target.objectType,
 		})
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// State written before object_type existed has no value for it; fill it
+	// in so the default does not force replacement.
+	state.ObjectType = types.StringValue(target.objectType)
+	state.Schema = stringOrNull(target.schema)
+	state.ObjectName = stringOrNull(target.objectName)
+	state.Access = stringOrNull(grant.Access)
+	state.Privileges = types.SetNull(types.StringType)
+	if grant.Access == "" {
+		privileges, diags := types.SetValueFrom(ctx, types.StringType, grant.Privileges)
+		resp.Diagnostics.Append(diags...)
+		state.Privileges = privileges
+	}
+	state.WithGrantOption = types.BoolValue(grant.WithGrantOption)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -210,68 +367,257 @@ func (r *clusterGrantResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	target, err := targetFromModel(&plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid cluster ID",
 			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
 		return
 	}
 
-	// Re-apply grant with updated access level (ON CONFLICT DO UPDATE).
-	grantReq := client.ConfigGrantRequest{
-		Username: plan.Username.ValueString(),
-		Database: plan.Database.ValueString(),
-		Access:   plan.Access.ValueString(),
+	// Re-apply grant with updated access, privileges and grant option
+	// (ON CONFLICT DO UPDATE).
+	grantReq, diags := grantRequest(ctx, target, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, client.ConfigureRequest{
+	configResp, err := r.client.ConfigureWithRetry(ctx, target.clusterID, client.ConfigureRequest{
 		Grants: []client.ConfigGrantRequest{grantReq},
 	}, 2*time.Minute)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating cluster grant",
-			fmt.Sprintf("Could not update grant on cluster %d: %s", clusterID, err))
+			fmt.Sprintf("Could not update grant on cluster %d: %s", target.clusterID, err))
 		return
 	}
 
 	if configResp.JobID > 0 {
-		if err := r.client.WaitForJobComplete(ctx, clusterID, 5*time.Minute); err != nil {
+		if err := r.client.WaitForJobComplete(ctx, target.clusterID, 5*time.Minute); err != nil {
 			resp.Diagnostics.AddError("Error waiting for grant update",
-				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+				fmt.Sprintf("Configure job failed for cluster %d: %s", target.clusterID, err))
 			return
 		}
 	}
 
+	plan.Access = stringOrNull(grantReq.Access)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func (r *clusterGrantResource) Delete(ctx context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Grant revocation is not currently supported by the Rivestack API.
-	// Removing from Terraform state only.
-	tflog.Warn(ctx, "Grant revocation is not supported by the Rivestack API. The grant remains on the cluster but is removed from Terraform state.")
+func (r *clusterGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state clusterGrantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := parseGrantID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid resource ID",
+			fmt.Sprintf("Could not parse resource ID %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Revoking cluster grant", map[string]interface{}{
+		"cluster_id":  target.clusterID,
+		"username":    target.username,
+		"database":    target.database,
+		"object_type": target.objectType,
+	})
+
+	configResp, err := r.client.ConfigureWithRetry(ctx, target.clusterID, client.ConfigureRequest{
+		DeleteGrants: []client.ConfigGrantRequest{target.request()},
+	}, 2*time.Minute)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		if client.IsUnsupported(err) {
+			resp.Diagnostics.AddWarning("Grant not revoked",
+				fmt.Sprintf("The Rivestack API did not accept the revocation of %s's access to %s on cluster %d: %s. "+
+					"The grant remains on the cluster but is removed from Terraform state.", target.username, target, target.clusterID, err))
+			return
+		}
+		resp.Diagnostics.AddError("Error revoking cluster grant",
+			fmt.Sprintf("Could not revoke grant on cluster %d: %s", target.clusterID, err))
+		return
+	}
+
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, target.clusterID, 5*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for grant revocation",
+				fmt.Sprintf("Configure job failed for cluster %d: %s", target.clusterID, err))
+			return
+		}
+	}
+
+	// An API without revocation support ignores delete_grants; make sure the
+	// grant is really gone instead of silently leaving access in place.
+	cluster, err := r.client.GetCluster(ctx, target.clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not verify grant revocation on cluster %d: %s", target.clusterID, err))
+		return
+	}
+	if target.find(cluster.Grants) != nil {
+		resp.Diagnostics.AddWarning("Grant not revoked",
+			fmt.Sprintf("%s still has access to %s on cluster %d after the revocation request. "+
+				"The grant remains on the cluster but is removed from Terraform state.", target.username, target, target.clusterID))
+	}
 }
 
 func (r *clusterGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.SplitN(req.ID, "/", 3)
-	if len(parts) != 3 {
+	target, err := parseGrantID(req.ID)
+	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID",
-			"Import ID must be in the format: cluster_id/username/database")
+			"Import ID must be in the format: cluster_id/username/database or cluster_id/username/database/object_type/schema[/object_name]")
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), strconv.Itoa(target.clusterID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), target.username)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), target.database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_type"), target.objectType)...)
+	if target.schema != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema"), target.schema)...)
+	}
+	if target.objectName != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_name"), target.objectName)...)
+	}
+}
+
+// targetFromModel builds the grant target from the planned attributes.
+func targetFromModel(m *clusterGrantResourceModel) (grantTarget, error) {
+	clusterID, err := strconv.Atoi(m.ClusterID.ValueString())
+	if err != nil {
+		return grantTarget{}, err
+	}
+	return grantTarget{
+		clusterID:  clusterID,
+		username:   m.Username.ValueString(),
+		database:   m.Database.ValueString(),
+		objectType: m.ObjectType.ValueString(),
+		schema:     m.Schema.ValueString(),
+		objectName: m.ObjectName.ValueString(),
+	}, nil
+}
+
+// grantRequest builds the configure request for a planned grant. Grants
+// without explicit privileges get write access unless access is set.
+func grantRequest(ctx context.Context, target grantTarget, m *clusterGrantResourceModel) (client.ConfigGrantRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	req := target.request()
+	req.WithGrantOption = m.WithGrantOption.ValueBool()
+	if !m.Privileges.IsNull() && !m.Privileges.IsUnknown() {
+		diags.Append(m.Privileges.ElementsAs(ctx, &req.Privileges, false)...)
+	}
+	switch {
+	case !m.Access.IsNull() && !m.Access.IsUnknown():
+		req.Access = m.Access.ValueString()
+	case len(req.Privileges) == 0:
+		req.Access = "write"
+	}
+	return req, diags
 }
 
-func parseGrantID(id string) (int, string, string, error) {
-	parts := strings.SplitN(id, "/", 3)
-	if len(parts) != 3 {
-		return 0, "", "", fmt.Errorf("expected format: cluster_id/username/database")
+// request returns the grant identity as a configure request. The object type
+// is omitted for database grants, which is what older API versions expect.
+func (t grantTarget) request() client.ConfigGrantRequest {
+	req := client.ConfigGrantRequest{
+		Username: t.username,
+		Database: t.database,
+	}
+	if t.objectType != "database" {
+		req.ObjectType = t.objectType
+		req.Schema = t.schema
+		req.ObjectName = t.objectName
+	}
+	return req
+}
+
+// find returns the grant matching the target, or nil.
+func (t grantTarget) find(grants []client.ClusterGrant) *client.ClusterGrant {
+	for i := range grants {
+		g := &grants[i]
+		if g.Username != t.username || g.Database != t.database {
+			continue
+		}
+		if t.objectType == "database" {
+			if g.DatabaseWide() {
+				return g
+			}
+			continue
+		}
+		if g.ObjectType == t.objectType && g.Schema == t.schema && g.ObjectName == t.objectName {
+			return g
+		}
+	}
+	return nil
+}
+
+// id returns the resource ID. Database grants keep the original
+// cluster_id/username/database format.
+func (t grantTarget) id() string {
+	id := fmt.Sprintf("%d/%s/%s", t.clusterID, t.username, t.database)
+	if t.objectType == "database" {
+		return id
+	}
+	id += "/" + t.objectType + "/" + t.schema
+	if t.objectName != "" {
+		id += "/" + t.objectName
+	}
+	return id
+}
+
+// String describes the granted object for diagnostics.
+func (t grantTarget) String() string {
+	switch {
+	case t.objectType == "database":
+		return fmt.Sprintf("database %q", t.database)
+	case t.objectType == "schema":
+		return fmt.Sprintf("schema %q in %q", t.schema, t.database)
+	case t.objectName == "":
+		return fmt.Sprintf("all %ss in schema %q in %q", t.objectType, t.schema, t.database)
+	default:
+		return fmt.Sprintf("%s %q in %q", t.objectType, t.schema+"."+t.objectName, t.database)
+	}
+}
+
+func parseGrantID(id string) (grantTarget, error) {
+	parts := strings.SplitN(id, "/", 6)
+	if len(parts) != 3 && len(parts) != 5 && len(parts) != 6 {
+		return grantTarget{}, fmt.Errorf("expected format: cluster_id/username/database[/object_type/schema[/object_name]]")
 	}
 	clusterID, err := strconv.Atoi(parts[0])
 	if err != nil {
-		return 0, "", "", fmt.Errorf("invalid cluster ID: %w", err)
+		return grantTarget{}, fmt.Errorf("invalid cluster ID: %w", err)
+	}
+	target := grantTarget{
+		clusterID:  clusterID,
+		username:   parts[1],
+		database:   parts[2],
+		objectType: "database",
+	}
+	if len(parts) >= 5 {
+		if _, ok := privilegesByObjectType[parts[3]]; !ok || parts[3] == "database" {
+			return grantTarget{}, fmt.Errorf("invalid object type %q", parts[3])
+		}
+		target.objectType = parts[3]
+		target.schema = parts[4]
+	}
+	if len(parts) == 6 {
+		target.objectName = parts[5]
+	}
+	return target, nil
+}
+
+func stringOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
 	}
-	return clusterID, parts[1], parts[2], nil
+	return types.StringValue(s)
 }
@@ -0,0 +1,66 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_grant
+
+import (
+	"testing"
+)
+
+func TestParseGrantID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want grantTarget
+	}{
+		{
+			id:   "42/reader/myapp",
+			want: grantTarget{clusterID: 42, username: "reader", database: "myapp", objectType: "database"},
+		},
+		{
+			id:   "42/reader/myapp/schema/reporting",
+			want: grantTarget{clusterID: 42, username: "reader", database: "myapp", objectType: "schema", schema: "reporting"},
+		},
+		{
+			id:   "42/reader/myapp/table/public",
+			want: grantTarget{clusterID: 42, username: "reader", database: "myapp", objectType: "table", schema: "public"},
+		},
+		{
+			id:   "42/reader/myapp/table/public/orders",
+			want: grantTarget{clusterID: 42, username: "reader", database: "myapp", objectType: "table", schema: "public", objectName: "orders"},
+		},
+		{
+			id:   "42/reader/myapp/function/public/calc_total(integer, numeric)",
+			want: grantTarget{clusterID: 42, username: "reader", database: "myapp", objectType: "function", schema: "public", objectName: "calc_total(integer, numeric)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := parseGrantID(tt.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if got.id() != tt.id {
+				t.Errorf("expected id %q, got %q", tt.id, got.id())
+			}
+		})
+	}
+}
+
+func TestParseGrantID_Invalid(t *testing.T) {
+	for _, id := range []string{
+		"42/reader",
+		"42/reader/myapp/table",
+		"abc/reader/myapp",
+		"42/reader/myapp/database/public",
+		"42/reader/myapp/database/public/myapp",
+		"42/reader/myapp/view/public",
+	} {
+		if _, err := parseGrantID(id); err == nil {
+			t.Errorf("expected error for %q", id)
+		}
+	}
+}
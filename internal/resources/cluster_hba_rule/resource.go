@@ -0,0 +1,348 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_hba_rule
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+var (
+	_ resource.Resource                   = &clusterHBARuleResource{}
+	_ resource.ResourceWithImportState    = &clusterHBARuleResource{}
+	_ resource.ResourceWithValidateConfig = &clusterHBARuleResource{}
+)
+
+func NewResource() resource.Resource {
+	return &clusterHBARuleResource{}
+}
+
+type clusterHBARuleResource struct {
+	client *client.Client
+}
+
+type clusterHBARuleResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ClusterID types.String `tfsdk:"cluster_id"`
+	Username  types.String `tfsdk:"username"`
+	Database  types.String `tfsdk:"database"`
+	CIDR      types.String `tfsdk:"cidr"`
+	Method    types.String `tfsdk:"method"`
+}
+
+func (r *clusterHBARuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_hba_rule"
+}
+
+func (r *clusterHBARuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a pg_hba access rule on a Rivestack HA PostgreSQL cluster, restricting where a user may connect from. Rules apply in addition to the cluster-wide IP allowlist.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (cluster_id/username/database/cidr).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username the rule applies to, or all.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Database the rule applies to, or all. Defaults to all.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("all"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Description: "Client address range in CIDR notation (e.g., 10.20.0.0/16).",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Description: "Authentication method: scram-sha-256, md5, cert, or reject. Defaults to scram-sha-256.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("scram-sha-256"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("scram-sha-256", "md5", "cert", "reject"),
+				},
+			},
+		},
+	}
+}
+
+func (r *clusterHBARuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *clusterHBARuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cidr types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cidr"), &cidr)...)
+	if resp.Diagnostics.HasError() || cidr.IsNull() || cidr.IsUnknown() {
+		return
+	}
+
+	ip, network, err := net.ParseCIDR(cidr.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid CIDR",
+			fmt.Sprintf("Could not parse %q as a CIDR range: %s", cidr.ValueString(), err))
+		return
+	}
+	// The API stores the network address, so host bits would show up as drift.
+	if !ip.Equal(network.IP) {
+		resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid CIDR",
+			fmt.Sprintf("%q has host bits set. Use the network address %q instead.", cidr.ValueString(), network.String()))
+	}
+}
+
+func (r *clusterHBARuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clusterHBARuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Creating cluster HBA rule", map[string]interface{}{
+		"cluster_id": clusterID,
+		"username":   plan.Username.ValueString(),
+		"database":   plan.Database.ValueString(),
+		"cidr":       plan.CIDR.ValueString(),
+		"method":     plan.Method.ValueString(),
+	})
+
+	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, client.ConfigureRequest{
+		HBARules: []client.ConfigHBARuleRequest{hbaRuleRequest(plan)},
+	}, 2*time.Minute)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating cluster HBA rule",
+			fmt.Sprintf("Could not create HBA rule on cluster %d: %s", clusterID, err))
+		return
+	}
+
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, clusterID, 5*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for HBA rule creation",
+				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d/%s/%s/%s", clusterID, plan.Username.ValueString(),
+		plan.Database.ValueString(), plan.CIDR.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterHBARuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clusterHBARuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, username, database, cidr, err := parseHBARuleID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid resource ID",
+			fmt.Sprintf("Could not parse resource ID %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	found := false
+	for _, rule := range cluster.HBARules {
+		if rule.Username == username && rule.Database == database && rule.CIDR == cidr {
+			state.Method = types.StringValue(rule.Method)
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		tflog.Warn(ctx, "Cluster HBA rule not found, removing from state", map[string]interface{}{
+			"cluster_id": clusterID,
+			"username":   username,
+			"database":   database,
+			"cidr":       cidr,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *clusterHBARuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan clusterHBARuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	// Only the method can change in place; re-applying the rule replaces it.
+	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, client.ConfigureRequest{
+		HBARules: []client.ConfigHBARuleRequest{hbaRuleRequest(plan)},
+	}, 2*time.Minute)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating cluster HBA rule",
+			fmt.Sprintf("Could not update HBA rule on cluster %d: %s", clusterID, err))
+		return
+	}
+
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, clusterID, 5*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for HBA rule update",
+				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterHBARuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state clusterHBARuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, username, database, cidr, err := parseHBARuleID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid resource ID",
+			fmt.Sprintf("Could not parse resource ID %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Deleting cluster HBA rule", map[string]interface{}{
+		"cluster_id": clusterID,
+		"username":   username,
+		"database":   database,
+		"cidr":       cidr,
+	})
+
+	configResp, err := r.client.ConfigureWithRetry(ctx, clusterID, client.ConfigureRequest{
+		DeleteHBARules: []client.ConfigHBARuleRequest{{Username: username, Database: database, CIDR: cidr}},
+	}, 2*time.Minute)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting cluster HBA rule",
+			fmt.Sprintf("Could not delete HBA rule from cluster %d: %s", clusterID, err))
+		return
+	}
+
+	if configResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, clusterID, 5*time.Minute); err != nil {
+			resp.Diagnostics.AddError("Error waiting for HBA rule deletion",
+				fmt.Sprintf("Configure job failed for cluster %d: %s", clusterID, err))
+			return
+		}
+	}
+}
+
+func (r *clusterHBARuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, username, database, cidr, err := parseHBARuleID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID",
+			"Import ID must be in the format: cluster_id/username/database/cidr")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), strconv.Itoa(clusterID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), username)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cidr"), cidr)...)
+}
+
+func hbaRuleRequest(m clusterHBARuleResourceModel) client.ConfigHBARuleRequest {
+	return client.ConfigHBARuleRequest{
+		Username: m.Username.ValueString(),
+		Database: m.Database.ValueString(),
+		CIDR:     m.CIDR.ValueString(),
+		Method:   m.Method.ValueString(),
+	}
+}
+
+// parseHBARuleID splits an ID of the form cluster_id/username/database/cidr.
+// The CIDR itself contains a slash, so it is everything after the third one.
+func parseHBARuleID(id string) (int, string, string, string, error) {
+	parts := strings.SplitN(id, "/", 4)
+	if len(parts) != 4 {
+		return 0, "", "", "", fmt.Errorf("expected format: cluster_id/username/database/cidr")
+	}
+	clusterID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", "", fmt.Errorf("invalid cluster ID: %w", err)
+	}
+	return clusterID, parts[1], parts[2], parts[3], nil
+}
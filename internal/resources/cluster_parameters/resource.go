@@ -0,0 +1,410 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_parameters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+var (
+	_ resource.Resource                = &clusterParametersResource{}
+	_ resource.ResourceWithImportState = &clusterParametersResource{}
+	_ resource.ResourceWithModifyPlan  = &clusterParametersResource{}
+)
+
+func NewResource() resource.Resource {
+	return &clusterParametersResource{}
+}
+
+type clusterParametersResource struct {
+	client *client.Client
+}
+
+type clusterParametersResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ClusterID      types.String `tfsdk:"cluster_id"`
+	Parameters     types.Map    `tfsdk:"parameters"`
+	RollingRestart types.Bool   `tfsdk:"rolling_restart"`
+	PendingRestart types.Bool   `tfsdk:"pending_restart"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+func (r *clusterParametersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_parameters"
+}
+
+func (r *clusterParametersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages PostgreSQL server parameters (postgresql.conf) of a Rivestack HA PostgreSQL cluster. Parameters removed from the map, or all parameters when this resource is destroyed, are reset to their defaults.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (cluster_id).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parameters": schema.MapAttribute{
+				Description: "Server parameters to set, keyed by name (e.g., work_mem = \"64MB\"). Memory and time values accept PostgreSQL unit suffixes (kB, MB, GB, TB, ms, s, min, h, d). Values are validated against the API's parameter catalog at plan time.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"rolling_restart": schema.BoolAttribute{
+				Description: "Perform a rolling restart when a changed parameter requires one. When false, such changes stay pending until the next restart. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"pending_restart": schema.BoolAttribute{
+				Description: "Whether changed parameters are waiting for a restart to take effect.",
+				Computed:    true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Last update timestamp.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *clusterParametersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *clusterParametersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan clusterParametersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Parameters.IsUnknown() {
+		return
+	}
+
+	prior := map[string]string{}
+	if !req.State.Raw.IsNull() {
+		var state clusterParametersResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		prior = mapToStrings(state.Parameters)
+	}
+
+	defs := r.parameterDefinitions(ctx)
+	if defs == nil {
+		return
+	}
+
+	var restartParams []string
+	for name, value := range mapToStrings(plan.Parameters) {
+		def, ok := defs[name]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("parameters").AtMapKey(name), "Unsupported parameter",
+				fmt.Sprintf("Parameter %q is not tunable on Rivestack clusters.", name))
+			continue
+		}
+		if err := def.Validate(value); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("parameters").AtMapKey(name), "Invalid parameter value", err.Error())
+			continue
+		}
+		if prev, ok := prior[name]; (!ok || !def.Equal(prev, value)) && def.RequiresRestart {
+			restartParams = append(restartParams, name)
+		}
+	}
+
+	if len(restartParams) > 0 {
+		sort.Strings(restartParams)
+		action := "A rolling restart of the cluster will be performed."
+		if !plan.RollingRestart.ValueBool() {
+			action = "rolling_restart is false, so the changes stay pending until the next restart."
+		}
+		resp.Diagnostics.AddAttributeWarning(path.Root("parameters"), "Parameter change requires a restart",
+			fmt.Sprintf("Changing %s requires a PostgreSQL restart. %s", strings.Join(restartParams, ", "), action))
+	}
+}
+
+func (r *clusterParametersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clusterParametersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Setting cluster parameters", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	if err := r.apply(ctx, clusterID, client.UpdateClusterParametersRequest{
+		Parameters:     mapToStrings(plan.Parameters),
+		RollingRestart: plan.RollingRestart.ValueBool(),
+	}); err != nil {
+		resp.Diagnostics.AddError("Error setting cluster parameters",
+			fmt.Sprintf("Could not set parameters on cluster %d: %s", clusterID, err))
+		return
+	}
+
+	params, err := r.client.GetClusterParameters(ctx, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster parameters",
+			fmt.Sprintf("Could not read parameters of cluster %d: %s", clusterID, err))
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ClusterID.ValueString())
+	plan.PendingRestart = types.BoolValue(params.PendingRestart)
+	plan.UpdatedAt = types.StringValue(params.UpdatedAt.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterParametersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clusterParametersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	params, err := r.client.GetClusterParameters(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster parameters",
+			fmt.Sprintf("Could not read parameters of cluster %d: %s", clusterID, err))
+		return
+	}
+
+	// Only report parameters managed by this resource; on import, take all
+	// overridden parameters.
+	current := params.Parameters
+	if !state.Parameters.IsNull() {
+		defs := r.parameterDefinitions(ctx)
+		current = make(map[string]string)
+		for name, prev := range mapToStrings(state.Parameters) {
+			value, ok := params.Parameters[name]
+			if !ok {
+				continue
+			}
+			// The API may report the value in another unit (65536 for
+			// 64MB); keep the configured spelling when the setting is the same.
+			if def, ok := defs[name]; ok && def.Equal(prev, value) {
+				value = prev
+			}
+			current[name] = value
+		}
+	}
+
+	elems := make(map[string]attr.Value, len(current))
+	for name, value := range current {
+		elems[name] = types.StringValue(value)
+	}
+	state.Parameters = types.MapValueMust(types.StringType, elems)
+	state.PendingRestart = types.BoolValue(params.PendingRestart)
+	state.UpdatedAt = types.StringValue(params.UpdatedAt.Format(time.RFC3339))
+	if state.RollingRestart.IsNull() {
+		state.RollingRestart = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *clusterParametersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state clusterParametersResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	planned := mapToStrings(plan.Parameters)
+	prior := mapToStrings(state.Parameters)
+
+	updateReq := client.UpdateClusterParametersRequest{
+		Parameters:     map[string]string{},
+		RollingRestart: plan.RollingRestart.ValueBool(),
+	}
+	for name, value := range planned {
+		if prev, ok := prior[name]; !ok || prev != value {
+			updateReq.Parameters[name] = value
+		}
+	}
+	for name := range prior {
+		if _, ok := planned[name]; !ok {
+			updateReq.Reset = append(updateReq.Reset, name)
+		}
+	}
+	sort.Strings(updateReq.Reset)
+
+	if len(updateReq.Parameters) > 0 || len(updateReq.Reset) > 0 {
+		tflog.Info(ctx, "Updating cluster parameters", map[string]interface{}{
+			"cluster_id": clusterID,
+			"reset":      updateReq.Reset,
+		})
+
+		if err := r.apply(ctx, clusterID, updateReq); err != nil {
+			resp.Diagnostics.AddError("Error updating cluster parameters",
+				fmt.Sprintf("Could not update parameters on cluster %d: %s", clusterID, err))
+			return
+		}
+	}
+
+	params, err := r.client.GetClusterParameters(ctx, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster parameters",
+			fmt.Sprintf("Could not read parameters of cluster %d: %s", clusterID, err))
+		return
+	}
+
+	plan.PendingRestart = types.BoolValue(params.PendingRestart)
+	plan.UpdatedAt = types.StringValue(params.UpdatedAt.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterParametersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state clusterParametersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	var reset []string
+	for name := range mapToStrings(state.Parameters) {
+		reset = append(reset, name)
+	}
+	if len(reset) == 0 {
+		return
+	}
+	sort.Strings(reset)
+
+	tflog.Info(ctx, "Resetting cluster parameters", map[string]interface{}{
+		"cluster_id": clusterID,
+		"reset":      reset,
+	})
+
+	err = r.apply(ctx, clusterID, client.UpdateClusterParametersRequest{
+		Reset:          reset,
+		RollingRestart: state.RollingRestart.ValueBool(),
+	})
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error resetting cluster parameters",
+			fmt.Sprintf("Could not reset parameters on cluster %d: %s", clusterID, err))
+		return
+	}
+}
+
+func (r *clusterParametersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), req.ID)...)
+}
+
+// apply sends a parameter update and waits for the resulting job, which
+// includes the rolling restart when one is required.
+func (r *clusterParametersResource) apply(ctx context.Context, clusterID int, req client.UpdateClusterParametersRequest) error {
+	updateResp, err := r.client.UpdateClusterParameters(ctx, clusterID, req)
+	if err != nil {
+		return err
+	}
+	if updateResp.JobID > 0 {
+		if err := r.client.WaitForJobComplete(ctx, clusterID, 30*time.Minute); err != nil {
+			return fmt.Errorf("parameter job failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// parameterDefinitions returns the parameter catalog keyed by name, or nil if
+// it cannot be fetched.
+func (r *clusterParametersResource) parameterDefinitions(ctx context.Context) map[string]client.ParameterDefinition {
+	catalog, err := r.client.CachedParameterCatalog(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not fetch parameter catalog", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	defs := make(map[string]client.ParameterDefinition, len(catalog.Parameters))
+	for _, def := range catalog.Parameters {
+		defs[def.Name] = def
+	}
+	return defs
+}
+
+func mapToStrings(m types.Map) map[string]string {
+	result := make(map[string]string)
+	if m.IsNull() || m.IsUnknown() {
+		return result
+	}
+	for name, v := range m.Elements() {
+		if s, ok := v.(types.String); ok {
+			result[name] = s.ValueString()
+		}
+	}
+	return result
+}
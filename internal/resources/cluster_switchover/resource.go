@@ -0,0 +1,201 @@
+// Copyright (c) Rivestack
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster_switchover
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/rivestack/terraform-provider-rivestack/internal/client"
+)
+
+var _ resource.Resource = &clusterSwitchoverResource{}
+
+func NewResource() resource.Resource {
+	return &clusterSwitchoverResource{}
+}
+
+type clusterSwitchoverResource struct {
+	client *client.Client
+}
+
+type clusterSwitchoverResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	ClusterID  types.String `tfsdk:"cluster_id"`
+	TargetNode types.String `tfsdk:"target_node"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	Leader     types.String `tfsdk:"leader"`
+}
+
+func (r *clusterSwitchoverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_switchover"
+}
+
+func (r *clusterSwitchoverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Performs a controlled switchover of the primary role to a chosen replica of a Rivestack HA PostgreSQL cluster. The switchover runs on create and whenever target_node or triggers change; destroying this resource removes it from Terraform state only.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (cluster_id/target_node).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_node": schema.StringAttribute{
+				Description: "Name of the replica node that should become the new leader.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that perform the switchover again when changed.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"leader": schema.StringAttribute{
+				Description: "Name of the current leader node of the cluster.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *clusterSwitchoverResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *clusterSwitchoverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clusterSwitchoverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", plan.ClusterID.ValueString(), err))
+		return
+	}
+
+	targetNode := plan.TargetNode.ValueString()
+
+	tflog.Info(ctx, "Switching over cluster leader", map[string]interface{}{
+		"cluster_id":  clusterID,
+		"target_node": targetNode,
+	})
+
+	_, err = r.client.Switchover(ctx, clusterID, targetNode)
+	if err != nil {
+		resp.Diagnostics.AddError("Error performing switchover",
+			fmt.Sprintf("Could not switch over cluster %d to node %q: %s", clusterID, targetNode, err))
+		return
+	}
+
+	if err := r.client.WaitForJobComplete(ctx, clusterID, 10*time.Minute); err != nil {
+		resp.Diagnostics.AddError("Error waiting for switchover job",
+			fmt.Sprintf("Switchover job failed for cluster %d: %s", clusterID, err))
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster after switchover",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	leader := findLeader(cluster.Nodes)
+	if leader != targetNode {
+		resp.Diagnostics.AddWarning("Switchover target is not the leader",
+			fmt.Sprintf("The switchover job completed, but the leader of cluster %d is %q instead of %q.", clusterID, leader, targetNode))
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d/%s", clusterID, targetNode))
+	plan.Leader = types.StringValue(leader)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *clusterSwitchoverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clusterSwitchoverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID, err := strconv.Atoi(state.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cluster ID",
+			fmt.Sprintf("Could not parse cluster ID %q: %s", state.ClusterID.ValueString(), err))
+		return
+	}
+
+	cluster, err := r.client.GetCluster(ctx, clusterID)
+	if err != nil {
+		if client.IsNotFound(err) || client.IsGone(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading cluster",
+			fmt.Sprintf("Could not read cluster %d: %s", clusterID, err))
+		return
+	}
+
+	// The leader may move again after a failover; report it without
+	// triggering another switchover.
+	state.Leader = types.StringValue(findLeader(cluster.Nodes))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *clusterSwitchoverResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes are ForceNew, so Update is never called.
+	resp.Diagnostics.AddError("Update not supported", "Cluster switchover attributes cannot be updated in-place.")
+}
+
+func (r *clusterSwitchoverResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// A switchover cannot be undone; removing from Terraform state only.
+	tflog.Info(ctx, "Removing cluster switchover from state; the current leader is left unchanged.")
+}
+
+func findLeader(nodes []client.ClusterNode) string {
+	for _, n := range nodes {
+		if n.Role == "leader" {
+			return n.Name
+		}
+	}
+	return ""
+}